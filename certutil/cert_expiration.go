@@ -0,0 +1,59 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// CertExpiration is the validity window for a certificate.
+type CertExpiration struct {
+	CommonName   string
+	SerialNumber *big.Int
+	NotBefore    time.Time
+	NotAfter     time.Time
+}
+
+// CertExpirations returns the validity windows for each certificate in a (possibly joined) pem bundle.
+func CertExpirations(pem string) ([]CertExpiration, error) {
+	certs, err := ParseCertPEM([]byte(pem))
+	if err != nil {
+		return nil, err
+	}
+	output := make([]CertExpiration, len(certs))
+	for index, cert := range certs {
+		output[index] = CertExpiration{
+			CommonName:   cert.Subject.CommonName,
+			SerialNumber: cert.SerialNumber,
+			NotBefore:    cert.NotBefore,
+			NotAfter:     cert.NotAfter,
+		}
+	}
+	return output, nil
+}
+
+// SoonestExpiration returns the earliest `NotAfter` across the certificates in a pem bundle.
+func SoonestExpiration(pem string) (time.Time, error) {
+	expirations, err := CertExpirations(pem)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(expirations) == 0 {
+		return time.Time{}, ex.New(ErrCertBundleEmpty)
+	}
+	soonest := expirations[0].NotAfter
+	for _, expiration := range expirations[1:] {
+		if expiration.NotAfter.Before(soonest) {
+			soonest = expiration.NotAfter
+		}
+	}
+	return soonest, nil
+}
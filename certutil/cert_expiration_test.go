@@ -0,0 +1,44 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func Test_CertExpirations(t *testing.T) {
+	its := assert.New(t)
+
+	notAfter := time.Now().UTC().AddDate(0, 1, 0).Truncate(time.Second)
+	ca, err := CreateCertificateAuthority(OptSubjectCommonName("ca"))
+	its.Nil(err)
+	server, err := CreateServer("server", ca, OptNotAfter(notAfter))
+	its.Nil(err)
+	certPEM, err := server.CertPEM()
+	its.Nil(err)
+
+	expirations, err := CertExpirations(string(certPEM))
+	its.Nil(err)
+	its.Len(expirations, 2)
+	its.Equal("server", expirations[0].CommonName)
+	its.Equal(server.Certificates[0].SerialNumber.String(), expirations[0].SerialNumber.String())
+	its.True(notAfter.Equal(expirations[0].NotAfter))
+	its.Equal("ca", expirations[1].CommonName)
+	its.True(expirations[1].NotAfter.After(notAfter))
+
+	soonest, err := SoonestExpiration(string(certPEM))
+	its.Nil(err)
+	its.True(notAfter.Equal(soonest))
+
+	_, err = SoonestExpiration("")
+	its.True(ex.Is(err, ErrCertBundleEmpty))
+}
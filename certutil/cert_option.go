@@ -134,6 +134,24 @@ func OptPrivateKey(privateKey *rsa.PrivateKey) CertOption {
 	}
 }
 
+// OptKeyType sets the private key algorithm used when a key is generated.
+// It is currently only honored by `CreateSelfSignedCertificate`.
+func OptKeyType(keyType KeyType) CertOption {
+	return func(cco *CertOptions) error {
+		cco.KeyType = keyType
+		return nil
+	}
+}
+
+// OptValidFor sets the validity duration for the certificate.
+// It is ignored if a `NotAfter` is set explicitly.
+func OptValidFor(validFor time.Duration) CertOption {
+	return func(cco *CertOptions) error {
+		cco.ValidFor = validFor
+		return nil
+	}
+}
+
 // OptPrivateKeyFromPath reads a private key from a given path and parses it as PKCS1PrivateKey.
 func OptPrivateKeyFromPath(path string) CertOption {
 	return func(cco *CertOptions) error {
@@ -11,7 +11,10 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"math/big"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/blend/go-sdk/ex"
@@ -21,10 +24,53 @@ import (
 type CertOptions struct {
 	x509.Certificate
 	PrivateKey        *rsa.PrivateKey
+	KeyType           KeyType
+	ValidFor          time.Duration
 	NotBeforeProvider func() time.Time
 	NotAfterProvider  func() time.Time
 }
 
+// clone returns a copy of the cert options that doesn't share slices
+// (or the serial number) with the original, so options can be applied
+// to a copy of one of the package defaults without modifying it.
+func (co CertOptions) clone() CertOptions {
+	co.Subject.Country = cloneStrings(co.Subject.Country)
+	co.Subject.Organization = cloneStrings(co.Subject.Organization)
+	co.Subject.OrganizationalUnit = cloneStrings(co.Subject.OrganizationalUnit)
+	co.Subject.Locality = cloneStrings(co.Subject.Locality)
+	co.Subject.Province = cloneStrings(co.Subject.Province)
+	co.Subject.StreetAddress = cloneStrings(co.Subject.StreetAddress)
+	co.Subject.PostalCode = cloneStrings(co.Subject.PostalCode)
+	if co.Subject.ExtraNames != nil {
+		co.Subject.ExtraNames = append([]pkix.AttributeTypeAndValue(nil), co.Subject.ExtraNames...)
+	}
+	co.DNSNames = cloneStrings(co.DNSNames)
+	co.EmailAddresses = cloneStrings(co.EmailAddresses)
+	if co.IPAddresses != nil {
+		co.IPAddresses = append([]net.IP(nil), co.IPAddresses...)
+	}
+	if co.URIs != nil {
+		co.URIs = append([]*url.URL(nil), co.URIs...)
+	}
+	if co.ExtKeyUsage != nil {
+		co.ExtKeyUsage = append([]x509.ExtKeyUsage(nil), co.ExtKeyUsage...)
+	}
+	if co.ExtraExtensions != nil {
+		co.ExtraExtensions = append([]pkix.Extension(nil), co.ExtraExtensions...)
+	}
+	if co.SerialNumber != nil {
+		co.SerialNumber = new(big.Int).Set(co.SerialNumber)
+	}
+	return co
+}
+
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string(nil), values...)
+}
+
 // ResolveCertOptions resolves the common create cert options.
 func ResolveCertOptions(createOptions *CertOptions, options ...CertOption) error {
 	var err error
@@ -40,7 +86,12 @@ func ResolveCertOptions(createOptions *CertOptions, options ...CertOption) error
 			return ex.New(err)
 		}
 	}
+	return resolveCertDefaults(createOptions)
+}
 
+// resolveCertDefaults resolves the serial number and validity window
+// for a set of cert options.
+func resolveCertDefaults(createOptions *CertOptions) (err error) {
 	if createOptions.SerialNumber == nil {
 		serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 		createOptions.SerialNumber, err = rand.Int(rand.Reader, serialNumberLimit)
@@ -49,12 +100,15 @@ func ResolveCertOptions(createOptions *CertOptions, options ...CertOption) error
 		}
 	}
 
-	var output CertBundle
-	output.PrivateKey = createOptions.PrivateKey
-	output.PublicKey = &createOptions.PrivateKey.PublicKey
-
-	if createOptions.NotAfter.IsZero() && createOptions.NotAfterProvider != nil {
-		createOptions.NotAfter = createOptions.NotAfterProvider()
+	if createOptions.NotBefore.IsZero() && createOptions.NotBeforeProvider != nil {
+		createOptions.NotBefore = createOptions.NotBeforeProvider()
+	}
+	if createOptions.NotAfter.IsZero() && createOptions.ValidFor > 0 {
+		notBefore := createOptions.NotBefore
+		if notBefore.IsZero() {
+			notBefore = time.Now().UTC()
+		}
+		createOptions.NotAfter = notBefore.Add(createOptions.ValidFor)
 	}
 	if createOptions.NotAfter.IsZero() && createOptions.NotAfterProvider != nil {
 		createOptions.NotAfter = createOptions.NotAfterProvider()
@@ -11,11 +11,22 @@ package certutil
 const (
 	BlockTypeCertificate   = "CERTIFICATE"
 	BlockTypeRSAPrivateKey = "RSA PRIVATE KEY"
+	BlockTypeECPrivateKey  = "EC PRIVATE KEY"
+)
+
+// KeyType is a private key algorithm.
+type KeyType string
+
+// KeyTypes
+const (
+	KeyTypeRSA   KeyType = "rsa"
+	KeyTypeECDSA KeyType = "ecdsa"
 )
 
 // Not After defaults.
 const (
-	DefaultCANotAfterYears     = 10
-	DefaultClientNotAfterYears = 1
-	DefaultServerNotAfterYears = 5
+	DefaultCANotAfterYears         = 10
+	DefaultClientNotAfterYears     = 1
+	DefaultServerNotAfterYears     = 5
+	DefaultSelfSignedNotAfterYears = 1
 )
@@ -17,7 +17,7 @@ import (
 // CreateCertificateAuthority creates a ca cert bundle from a given set of options.
 // The cert bundle can be used to generate client and server certificates.
 func CreateCertificateAuthority(options ...CertOption) (*CertBundle, error) {
-	createOptions := DefaultOptionsCertificateAuthority
+	createOptions := DefaultOptionsCertificateAuthority.clone()
 
 	if err := ResolveCertOptions(&createOptions, options...); err != nil {
 		return nil, nil
@@ -32,7 +32,7 @@ func CreateClient(commonName string, ca *CertBundle, options ...CertOption) (*Ce
 		return nil, ex.New("must provide a ca cert bundle")
 	}
 
-	createOptions := DefaultOptionsClient
+	createOptions := DefaultOptionsClient.clone()
 	createOptions.Subject.CommonName = commonName
 	createOptions.DNSNames = []string{commonName}
 
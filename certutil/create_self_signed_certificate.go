@@ -0,0 +1,85 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ErrInvalidKeyType is returned when a key type is not one of the supported key types.
+const ErrInvalidKeyType ex.Class = "invalid key type"
+
+// CreateSelfSignedCertificate creates a self signed certificate and returns
+// the certificate and private key as pems.
+//
+// By default the certificate uses an ECDSA P-256 key, is valid for one year
+// and has a single `localhost` subject alternate name.
+func CreateSelfSignedCertificate(options ...CertOption) (certPEM, keyPEM string, err error) {
+	createOptions := DefaultOptionsSelfSigned.clone()
+	for _, option := range options {
+		if err = option(&createOptions); err != nil {
+			return
+		}
+	}
+	if err = resolveCertDefaults(&createOptions); err != nil {
+		return
+	}
+
+	var signer crypto.Signer
+	var keyBlock *pem.Block
+	switch createOptions.KeyType {
+	case KeyTypeECDSA:
+		var privateKey *ecdsa.PrivateKey
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			err = ex.New(err)
+			return
+		}
+		var keyDER []byte
+		keyDER, err = x509.MarshalECPrivateKey(privateKey)
+		if err != nil {
+			err = ex.New(err)
+			return
+		}
+		// ecdsa keys cannot be used for key encipherment
+		createOptions.KeyUsage &^= x509.KeyUsageKeyEncipherment
+		signer = privateKey
+		keyBlock = &pem.Block{Type: BlockTypeECPrivateKey, Bytes: keyDER}
+	case KeyTypeRSA, "":
+		privateKey := createOptions.PrivateKey
+		if privateKey == nil {
+			privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				err = ex.New(err)
+				return
+			}
+		}
+		signer = privateKey
+		keyBlock = &pem.Block{Type: BlockTypeRSAPrivateKey, Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}
+	default:
+		err = ex.New(ErrInvalidKeyType, ex.OptMessagef("key type: %s", createOptions.KeyType))
+		return
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &createOptions.Certificate, &createOptions.Certificate, signer.Public(), signer)
+	if err != nil {
+		err = ex.New(err)
+		return
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: BlockTypeCertificate, Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(keyBlock))
+	return
+}
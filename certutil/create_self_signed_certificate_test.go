@@ -0,0 +1,101 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func Test_CreateSelfSignedCertificate(t *testing.T) {
+	t.Parallel()
+	its := assert.New(t)
+
+	certPEM, keyPEM, err := CreateSelfSignedCertificate()
+	its.Nil(err)
+
+	pair, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	its.Nil(err)
+	_, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	its.True(ok)
+
+	certs, err := ParseCertPEM([]byte(certPEM))
+	its.Nil(err)
+	its.Len(certs, 1)
+	its.Equal("localhost", certs[0].Subject.CommonName)
+	its.Equal([]string{"localhost"}, certs[0].DNSNames)
+	its.True(certs[0].NotAfter.After(time.Now().UTC().AddDate(0, 11, 0)))
+	its.True(certs[0].NotAfter.Before(time.Now().UTC().AddDate(1, 0, 1)))
+}
+
+func Test_CreateSelfSignedCertificate_options(t *testing.T) {
+	t.Parallel()
+	its := assert.New(t)
+
+	certPEM, keyPEM, err := CreateSelfSignedCertificate(
+		OptSubjectCommonName("foo.bar.com"),
+		OptDNSNames("foo.bar.com", "bar.com"),
+		OptValidFor(time.Hour),
+		OptKeyType(KeyTypeRSA),
+	)
+	its.Nil(err)
+
+	pair, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	its.Nil(err)
+	_, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	its.True(ok)
+
+	certs, err := ParseCertPEM([]byte(certPEM))
+	its.Nil(err)
+	its.Len(certs, 1)
+	its.Equal("foo.bar.com", certs[0].Subject.CommonName)
+	its.Equal([]string{"foo.bar.com", "bar.com"}, certs[0].DNSNames)
+	its.True(certs[0].NotAfter.Before(time.Now().UTC().Add(2 * time.Hour)))
+
+	_, _, err = CreateSelfSignedCertificate(OptKeyType("dsa"))
+	its.True(ex.Is(err, ErrInvalidKeyType))
+
+	// options don't modify the defaults.
+	its.Equal([]string{"localhost"}, DefaultOptionsSelfSigned.DNSNames)
+	its.Equal("localhost", DefaultOptionsSelfSigned.Subject.CommonName)
+}
+
+func Test_CertOptions_clone(t *testing.T) {
+	its := assert.New(t)
+
+	original := CertOptions{
+		Certificate: x509.Certificate{
+			DNSNames:     make([]string, 1, 4),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			SerialNumber: big.NewInt(1),
+		},
+	}
+	original.Subject.Organization = []string{"blend"}
+
+	clone := original.clone()
+	clone.DNSNames[0] = "foo.bar.com"
+	its.Nil(OptAddDNSNames("bar.com")(&clone))
+	clone.ExtKeyUsage[0] = x509.ExtKeyUsageClientAuth
+	clone.Subject.Organization[0] = "other"
+	clone.SerialNumber.SetInt64(2)
+
+	its.Equal([]string{"foo.bar.com", "bar.com"}, clone.DNSNames)
+	its.Equal([]string{""}, original.DNSNames)
+	its.Equal("", original.DNSNames[:2][1])
+	its.Equal(x509.ExtKeyUsageServerAuth, original.ExtKeyUsage[0])
+	its.Equal("blend", original.Subject.Organization[0])
+	its.Equal(1, original.SerialNumber.Int64())
+}
@@ -20,7 +20,7 @@ func CreateServer(commonName string, ca *CertBundle, options ...CertOption) (*Ce
 		return nil, ex.New("provided certificate authority bundle is invalid")
 	}
 
-	createOptions := DefaultOptionsServer
+	createOptions := DefaultOptionsServer.clone()
 	// set the default common name
 	createOptions.Subject.CommonName = commonName
 	// it is important to reflect the common name here as well
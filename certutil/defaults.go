@@ -9,6 +9,7 @@ package certutil
 
 import (
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"time"
 )
 
@@ -39,3 +40,17 @@ var DefaultOptionsClient = CertOptions{
 	},
 	NotAfterProvider: func() time.Time { return time.Now().UTC().AddDate(DefaultClientNotAfterYears, 0, 0) },
 }
+
+// DefaultOptionsSelfSigned are the default create cert options for self signed certificates.
+var DefaultOptionsSelfSigned = CertOptions{
+	Certificate: x509.Certificate{
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+	},
+	KeyType:           KeyTypeECDSA,
+	NotBeforeProvider: func() time.Time { return time.Now().UTC() },
+	NotAfterProvider:  func() time.Time { return time.Now().UTC().AddDate(DefaultSelfSignedNotAfterYears, 0, 0) },
+}
@@ -7,7 +7,15 @@ Use of this source code is governed by a MIT license that can be found in the LI
 
 package certutil
 
-import "strings"
+import (
+	"encoding/pem"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ErrInvalidPEM is returned by `JoinPEMsValidated` if an argument does not contain a pem block.
+const ErrInvalidPEM ex.Class = "argument does not contain a valid pem block"
 
 // JoinPEMs appends pem blocks together with newlines.
 //
@@ -28,3 +36,17 @@ func JoinPEMs(pems ...string) string {
 	}
 	return strings.Join(cleaned, "\n") + "\n"
 }
+
+// JoinPEMsValidated appends pem blocks together with newlines after confirming
+// each argument decodes to at least one pem block.
+//
+// It otherwise behaves exactly as `JoinPEMs`, which should be preferred when
+// the inputs are already known to be well formed.
+func JoinPEMsValidated(pems ...string) (string, error) {
+	for index, pemData := range pems {
+		if block, _ := pem.Decode([]byte(strings.TrimSpace(pemData))); block == nil {
+			return "", ex.New(ErrInvalidPEM, ex.OptMessagef("argument index: %d", index))
+		}
+	}
+	return JoinPEMs(pems...), nil
+}
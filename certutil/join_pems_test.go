@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
 )
 
 func Test_JoinPEMs(t *testing.T) {
@@ -30,3 +31,24 @@ func Test_JoinPEMs(t *testing.T) {
 
 	its.Equal(string(serverFull), serverJoined)
 }
+
+func Test_JoinPEMsValidated(t *testing.T) {
+	its := assert.New(t)
+
+	ca, err := ioutil.ReadFile("testdata/ca.cert.pem")
+	its.Nil(err)
+
+	serverPartial, err := ioutil.ReadFile("testdata/server.partial.cert.pem")
+	its.Nil(err)
+
+	serverFull, err := ioutil.ReadFile("testdata/server.cert.pem")
+	its.Nil(err)
+
+	serverJoined, err := JoinPEMsValidated(string(serverPartial), string(ca))
+	its.Nil(err)
+	its.Equal(string(serverFull), serverJoined)
+
+	_, err = JoinPEMsValidated(string(serverPartial), "not a pem", string(ca))
+	its.True(ex.Is(err, ErrInvalidPEM))
+	its.Contains(ex.ErrMessage(err), "argument index: 1")
+}
@@ -0,0 +1,103 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"bytes"
+	"crypto/x509"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// Errors
+const (
+	ErrCertBundleEmpty  ex.Class = "cert bundle contains no certificates"
+	ErrCertBundleNoLeaf ex.Class = "cert bundle contains no leaf certificate"
+)
+
+// ParseCertBundle parses a (possibly joined) pem bundle into the leaf certificate
+// and the remaining intermediate certificates.
+//
+// The leaf is the certificate that does not issue any other certificate in the bundle,
+// preferring non-CA certificates. Intermediates are returned ordered from the leaf's
+// issuer upwards, followed by any unrelated certificates in bundle order.
+func ParseCertBundle(pem string) (leaf *x509.Certificate, intermediates []*x509.Certificate, err error) {
+	certs, err := ParseCertPEM([]byte(pem))
+	if err != nil {
+		return
+	}
+	if len(certs) == 0 {
+		err = ex.New(ErrCertBundleEmpty)
+		return
+	}
+	if len(certs) == 1 {
+		leaf = certs[0]
+		return
+	}
+
+	leafIndex := -1
+	for index, cert := range certs {
+		if certIssuesAny(cert, certs) {
+			continue
+		}
+		if leafIndex < 0 || (certs[leafIndex].IsCA && !cert.IsCA) {
+			leafIndex = index
+		}
+	}
+	if leafIndex < 0 {
+		err = ex.New(ErrCertBundleNoLeaf)
+		return
+	}
+	leaf = certs[leafIndex]
+
+	used := make([]bool, len(certs))
+	used[leafIndex] = true
+	current := leaf
+	for {
+		next := -1
+		for index, cert := range certs {
+			if !used[index] && certIssued(cert, current) {
+				next = index
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+		used[next] = true
+		intermediates = append(intermediates, certs[next])
+		current = certs[next]
+	}
+	for index, cert := range certs {
+		if !used[index] {
+			intermediates = append(intermediates, cert)
+		}
+	}
+	return
+}
+
+// certIssuesAny returns if the issuer issued any other certificate in a given set.
+func certIssuesAny(issuer *x509.Certificate, certs []*x509.Certificate) bool {
+	for _, cert := range certs {
+		if cert == issuer {
+			continue
+		}
+		if certIssued(issuer, cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// certIssued returns if the issuer issued a given certificate.
+func certIssued(issuer, cert *x509.Certificate) bool {
+	if !bytes.Equal(issuer.RawSubject, cert.RawIssuer) {
+		return false
+	}
+	return cert.CheckSignatureFrom(issuer) == nil
+}
@@ -0,0 +1,62 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func Test_ParseCertBundle(t *testing.T) {
+	its := assert.New(t)
+
+	ca, err := CreateCertificateAuthority(OptSubjectCommonName("ca"))
+	its.Nil(err)
+	intermediate, err := CreateServer("intermediate", ca, func(co *CertOptions) error {
+		co.IsCA = true
+		co.BasicConstraintsValid = true
+		co.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign
+		return nil
+	})
+	its.Nil(err)
+	server, err := CreateServer("server", intermediate)
+	its.Nil(err)
+	its.Len(server.CertificateDERs, 3)
+
+	// the order within the bundle should not matter
+	leaf, intermediates, err := ParseCertBundle(JoinPEMs(
+		partialCertPEM(its, server.CertificateDERs[2]),
+		partialCertPEM(its, server.CertificateDERs[0]),
+		partialCertPEM(its, server.CertificateDERs[1]),
+	))
+	its.Nil(err)
+	its.NotNil(leaf)
+	its.Equal("server", leaf.Subject.CommonName)
+	its.Len(intermediates, 2)
+	its.Equal("intermediate", intermediates[0].Subject.CommonName)
+	its.Equal("ca", intermediates[1].Subject.CommonName)
+
+	leaf, intermediates, err = ParseCertBundle(partialCertPEM(its, server.CertificateDERs[0]))
+	its.Nil(err)
+	its.Equal("server", leaf.Subject.CommonName)
+	its.Empty(intermediates)
+
+	_, _, err = ParseCertBundle("")
+	its.True(ex.Is(err, ErrCertBundleEmpty))
+}
+
+func partialCertPEM(its *assert.Assertions, der []byte) string {
+	buffer := new(bytes.Buffer)
+	its.Nil(pem.Encode(buffer, &pem.Block{Type: BlockTypeCertificate, Bytes: der}))
+	return buffer.String()
+}
@@ -0,0 +1,72 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ErrCertificateNotPinned is returned by `PinnedVerify` when none of the verified certificates match a pin.
+const ErrCertificateNotPinned ex.Class = "no presented certificate matches a pinned public key"
+
+// PublicKeySHA256 returns the SHA-256 hash of a certificate's SubjectPublicKeyInfo,
+// which is the value pinned with `PinnedVerify`.
+func PublicKeySHA256(cert *x509.Certificate) []byte {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return sum[:]
+}
+
+// PinnedVerify returns a `tls.Config.VerifyPeerCertificate` func that pins certificates by the
+// SHA-256 hash of their SubjectPublicKeyInfo (see `PublicKeySHA256`).
+//
+// Verification fails unless a certificate in one of the verified chains (the leaf, an
+// intermediate or the root) matches one of the pins, so multiple pins can be given to rotate keys.
+// Other certificates the peer presents are ignored; they are not part of a verified chain.
+//
+// It runs in addition to the standard chain verification. If `InsecureSkipVerify` is set there
+// are no verified chains, and only the leaf certificate the peer presents is matched against the pins.
+func PinnedVerify(pinsSHA256 [][]byte) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(verifiedChains) > 0 {
+			for _, chain := range verifiedChains {
+				for _, cert := range chain {
+					if isPinned(pinsSHA256, cert) {
+						return nil
+					}
+				}
+			}
+			return ex.New(ErrCertificateNotPinned)
+		}
+		if len(rawCerts) == 0 {
+			return ex.New(ErrCertificateNotPinned)
+		}
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return ex.New(err)
+		}
+		if isPinned(pinsSHA256, leaf) {
+			return nil
+		}
+		return ex.New(ErrCertificateNotPinned)
+	}
+}
+
+// isPinned returns if a certificate's public key matches one of the pins.
+func isPinned(pinsSHA256 [][]byte, cert *x509.Certificate) bool {
+	hash := PublicKeySHA256(cert)
+	for _, pin := range pinsSHA256 {
+		if bytes.Equal(hash, pin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestPinnedVerify(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	ca, err := NewCertBundle(KeyPair{Cert: string(caCertLiteral), Key: string(caKeyLiteral)})
+	assert.Nil(err)
+	server, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+	other, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+
+	rawCerts := [][]byte{server.CertificateDERs[0], ca.CertificateDERs[0]}
+	serverPin := PublicKeySHA256(&server.Certificates[0])
+	caPin := PublicKeySHA256(&ca.Certificates[0])
+	otherPin := PublicKeySHA256(&other.Certificates[0])
+
+	assert.Nil(PinnedVerify([][]byte{serverPin})(rawCerts, nil))
+	// multiple pins allow for rotation.
+	assert.Nil(PinnedVerify([][]byte{otherPin, serverPin})(rawCerts, nil))
+
+	// without verified chains, only the leaf is matched.
+	err = PinnedVerify([][]byte{caPin})(rawCerts, nil)
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+
+	// with verified chains, any certificate in a chain is matched.
+	verifiedChains := [][]*x509.Certificate{{&server.Certificates[0], &ca.Certificates[0]}}
+	assert.Nil(PinnedVerify([][]byte{serverPin})(rawCerts, verifiedChains))
+	assert.Nil(PinnedVerify([][]byte{caPin})(rawCerts, verifiedChains))
+	err = PinnedVerify([][]byte{otherPin})(rawCerts, verifiedChains)
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+
+	// a pinned certificate appended after an unpinned leaf is ignored.
+	appended := [][]byte{other.CertificateDERs[0], server.CertificateDERs[0]}
+	err = PinnedVerify([][]byte{serverPin})(appended, nil)
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+	err = PinnedVerify([][]byte{serverPin})(appended, [][]*x509.Certificate{{&other.Certificates[0], &ca.Certificates[0]}})
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+
+	err = PinnedVerify([][]byte{otherPin})(rawCerts, nil)
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+	err = PinnedVerify(nil)(rawCerts, nil)
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+	err = PinnedVerify([][]byte{serverPin})(nil, nil)
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+
+	// pins are of the public key, not the whole certificate.
+	certHash := sha256.Sum256(server.CertificateDERs[0])
+	err = PinnedVerify([][]byte{certHash[:]})(rawCerts, nil)
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+
+	err = PinnedVerify([][]byte{serverPin})([][]byte{[]byte("not a certificate")}, nil)
+	assert.NotNil(err)
+	assert.False(ex.Is(err, ErrCertificateNotPinned))
+}
+
+func TestPinnedVerifyHandshake(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	ca, err := NewCertBundle(KeyPair{Cert: string(caCertLiteral), Key: string(caKeyLiteral)})
+	assert.Nil(err)
+	server, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+	other, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+	serverCertPEM, serverKeyPEM := pemsForTLSConfigTest(assert, server)
+	serverConfig, err := NewServerTLSConfig(serverCertPEM, serverKeyPEM, "")
+	assert.Nil(err)
+
+	handshake := func(pins ...[]byte) error {
+		clientConfig := &tls.Config{
+			InsecureSkipVerify:    true,
+			VerifyPeerCertificate: PinnedVerify(pins),
+		}
+		serverConn, clientConn := net.Pipe()
+		defer serverConn.Close()
+		defer clientConn.Close()
+		go func() { _ = tls.Server(serverConn, serverConfig).Handshake() }()
+		return tls.Client(clientConn, clientConfig).Handshake()
+	}
+
+	assert.Nil(handshake(PublicKeySHA256(&server.Certificates[0])))
+	assert.NotNil(handshake(PublicKeySHA256(&other.Certificates[0])))
+}
+
+func TestPinnedVerifyHandshakeAppendedCertificate(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	ca, err := NewCertBundle(KeyPair{Cert: string(caCertLiteral), Key: string(caKeyLiteral)})
+	assert.Nil(err)
+	pinned, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+	unpinned, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+
+	// the server presents an unpinned (but trusted) leaf, followed by the pinned certificate.
+	serverConfig := &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{unpinned.CertificateDERs[0], pinned.CertificateDERs[0]},
+			PrivateKey:  unpinned.PrivateKey,
+		}},
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(&ca.Certificates[0])
+
+	handshake := func(pins ...[]byte) error {
+		clientConfig := &tls.Config{
+			RootCAs:               rootCAs,
+			ServerName:            "localhost",
+			VerifyPeerCertificate: PinnedVerify(pins),
+		}
+		serverConn, clientConn := net.Pipe()
+		defer serverConn.Close()
+		defer clientConn.Close()
+		go func() { _ = tls.Server(serverConn, serverConfig).Handshake() }()
+		return tls.Client(clientConn, clientConfig).Handshake()
+	}
+
+	err = handshake(PublicKeySHA256(&pinned.Certificates[0]))
+	assert.True(ex.Is(err, ErrCertificateNotPinned))
+	assert.Nil(handshake(PublicKeySHA256(&unpinned.Certificates[0])))
+}
@@ -0,0 +1,152 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// DefaultTLSMinVersion is the default minimum tls version for configs created
+// with `NewServerTLSConfig` and `NewClientTLSConfigFromPEM`.
+const DefaultTLSMinVersion = tls.VersionTLS12
+
+// DefaultTLSCipherSuites are the default cipher suites, in preference order, for configs
+// created with `NewServerTLSConfig` and `NewClientTLSConfigFromPEM`.
+//
+// They only apply to TLS 1.2 connections; TLS 1.3 suites are not configurable.
+// The first suite must remain first as http/2 requires it.
+var DefaultTLSCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+}
+
+// TLSOption mutates a tls config.
+type TLSOption func(*tls.Config) error
+
+// OptTLSMinVersion sets the minimum tls version.
+func OptTLSMinVersion(version uint16) TLSOption {
+	return func(config *tls.Config) error {
+		config.MinVersion = version
+		return nil
+	}
+}
+
+// OptTLSCipherSuites sets the tls 1.2 cipher suites.
+func OptTLSCipherSuites(cipherSuites ...uint16) TLSOption {
+	return func(config *tls.Config) error {
+		config.CipherSuites = cipherSuites
+		return nil
+	}
+}
+
+// OptTLSClientAuth sets the client cert verification level for server configs.
+func OptTLSClientAuth(clientAuth tls.ClientAuthType) TLSOption {
+	return func(config *tls.Config) error {
+		config.ClientAuth = clientAuth
+		return nil
+	}
+}
+
+// OptTLSServerName sets the server name client configs verify certificates against.
+func OptTLSServerName(serverName string) TLSOption {
+	return func(config *tls.Config) error {
+		config.ServerName = serverName
+		return nil
+	}
+}
+
+// NewServerTLSConfig returns a new server tls config from a cert and key in PEM format.
+//
+// If `caPEM` is set, it is used as the pool of certificate authorities client certificates
+// are verified against, and client certificates are required; use `OptTLSClientAuth` to relax this.
+// The config has a minimum version of TLS 1.2 and uses `DefaultTLSCipherSuites`.
+func NewServerTLSConfig(certPEM, keyPEM, caPEM string, opts ...TLSOption) (*tls.Config, error) {
+	cert, err := x509KeyPairFromPEM(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	config := newDefaultTLSConfig()
+	config.Certificates = []tls.Certificate{cert}
+	config.PreferServerCipherSuites = true
+	if caPEM != "" {
+		config.ClientCAs = x509.NewCertPool()
+		if ok := config.ClientCAs.AppendCertsFromPEM([]byte(caPEM)); !ok {
+			return nil, ex.New(ErrInvalidCertPEM, ex.OptMessage("client certificate authorities"))
+		}
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if err = applyTLSOptions(config, opts...); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// NewClientTLSConfigFromPEM returns a new client tls config from a client cert and key in PEM format.
+// This is useful for making mutual tls calls to servers that require it.
+//
+// If `caPEM` is set, it is added to the system pool of certificate authorities the server
+// certificate is verified against. The config has a minimum version of TLS 1.2 and uses
+// `DefaultTLSCipherSuites`.
+func NewClientTLSConfigFromPEM(certPEM, keyPEM, caPEM string, opts ...TLSOption) (*tls.Config, error) {
+	cert, err := x509KeyPairFromPEM(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	config := newDefaultTLSConfig()
+	config.Certificates = []tls.Certificate{cert}
+	config.RootCAs, err = x509.SystemCertPool()
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	if caPEM != "" {
+		if ok := config.RootCAs.AppendCertsFromPEM([]byte(caPEM)); !ok {
+			return nil, ex.New(ErrInvalidCertPEM, ex.OptMessage("root certificate authorities"))
+		}
+	}
+	if err = applyTLSOptions(config, opts...); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func newDefaultTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:   DefaultTLSMinVersion,
+		CipherSuites: append([]uint16(nil), DefaultTLSCipherSuites...),
+	}
+}
+
+func x509KeyPairFromPEM(certPEM, keyPEM string) (tls.Certificate, error) {
+	if certPEM == "" {
+		return tls.Certificate{}, ex.New("invalid key pair; empty cert pem data")
+	}
+	if keyPEM == "" {
+		return tls.Certificate{}, ex.New("invalid key pair; empty key pem data")
+	}
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return tls.Certificate{}, ex.New(err)
+	}
+	return cert, nil
+}
+
+func applyTLSOptions(config *tls.Config, opts ...TLSOption) error {
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package certutil
+
+import (
+	"bytes"
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func pemsForTLSConfigTest(assert *assert.Assertions, bundle *CertBundle) (certPEM, keyPEM string) {
+	cert := new(bytes.Buffer)
+	assert.Nil(bundle.WriteCertPem(cert))
+	key := new(bytes.Buffer)
+	assert.Nil(bundle.WriteKeyPem(key))
+	return cert.String(), key.String()
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	ca, err := NewCertBundle(KeyPair{Cert: string(caCertLiteral), Key: string(caKeyLiteral)})
+	assert.Nil(err)
+	server, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+	serverCertPEM, serverKeyPEM := pemsForTLSConfigTest(assert, server)
+
+	config, err := NewServerTLSConfig(serverCertPEM, serverKeyPEM, "")
+	assert.Nil(err)
+	assert.Len(config.Certificates, 1)
+	assert.Equal(tls.VersionTLS12, config.MinVersion)
+	assert.Equal(DefaultTLSCipherSuites, config.CipherSuites)
+	assert.Equal(tls.NoClientCert, config.ClientAuth)
+	assert.Nil(config.ClientCAs)
+
+	config, err = NewServerTLSConfig(serverCertPEM, serverKeyPEM, string(caCertLiteral))
+	assert.Nil(err)
+	assert.NotNil(config.ClientCAs)
+	assert.Equal(tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	config, err = NewServerTLSConfig(serverCertPEM, serverKeyPEM, string(caCertLiteral),
+		OptTLSClientAuth(tls.VerifyClientCertIfGiven),
+		OptTLSMinVersion(tls.VersionTLS13),
+	)
+	assert.Nil(err)
+	assert.Equal(tls.VerifyClientCertIfGiven, config.ClientAuth)
+	assert.Equal(tls.VersionTLS13, config.MinVersion)
+
+	_, err = NewServerTLSConfig(serverCertPEM, serverKeyPEM, "not a pem")
+	assert.True(ex.Is(err, ErrInvalidCertPEM))
+	_, err = NewServerTLSConfig("", serverKeyPEM, "")
+	assert.NotNil(err)
+	_, err = NewServerTLSConfig(serverCertPEM, "", "")
+	assert.NotNil(err)
+	_, err = NewServerTLSConfig(serverCertPEM, string(caKeyLiteral), "")
+	assert.NotNil(err)
+
+	_, err = NewServerTLSConfig(serverCertPEM, serverKeyPEM, "", func(_ *tls.Config) error {
+		return ex.New("only a test")
+	})
+	assert.Equal("only a test", ex.ErrClass(err))
+}
+
+func TestNewClientTLSConfigFromPEM(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	ca, err := NewCertBundle(KeyPair{Cert: string(caCertLiteral), Key: string(caKeyLiteral)})
+	assert.Nil(err)
+	client, err := CreateClient("client", ca)
+	assert.Nil(err)
+	clientCertPEM, clientKeyPEM := pemsForTLSConfigTest(assert, client)
+
+	config, err := NewClientTLSConfigFromPEM(clientCertPEM, clientKeyPEM, string(caCertLiteral), OptTLSServerName("localhost"))
+	assert.Nil(err)
+	assert.Len(config.Certificates, 1)
+	assert.NotNil(config.RootCAs)
+	assert.Equal(tls.VersionTLS12, config.MinVersion)
+	assert.Equal("localhost", config.ServerName)
+
+	_, err = NewClientTLSConfigFromPEM(clientCertPEM, clientKeyPEM, "not a pem")
+	assert.True(ex.Is(err, ErrInvalidCertPEM))
+}
+
+func TestTLSConfigMutualHandshake(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	ca, err := NewCertBundle(KeyPair{Cert: string(caCertLiteral), Key: string(caKeyLiteral)})
+	assert.Nil(err)
+	server, err := CreateServer("localhost", ca)
+	assert.Nil(err)
+	client, err := CreateClient("client", ca)
+	assert.Nil(err)
+	serverCertPEM, serverKeyPEM := pemsForTLSConfigTest(assert, server)
+	clientCertPEM, clientKeyPEM := pemsForTLSConfigTest(assert, client)
+
+	serverConfig, err := NewServerTLSConfig(serverCertPEM, serverKeyPEM, string(caCertLiteral))
+	assert.Nil(err)
+	clientConfig, err := NewClientTLSConfigFromPEM(clientCertPEM, clientKeyPEM, string(caCertLiteral), OptTLSServerName("localhost"))
+	assert.Nil(err)
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	tlsServer := tls.Server(serverConn, serverConfig)
+	serverErrors := make(chan error, 1)
+	go func() { serverErrors <- tlsServer.Handshake() }()
+
+	tlsClient := tls.Client(clientConn, clientConfig)
+	assert.Nil(tlsClient.Handshake())
+	assert.Nil(<-serverErrors)
+
+	peers := tlsServer.ConnectionState().PeerCertificates
+	assert.NotEmpty(peers)
+	assert.Equal("client", peers[0].Subject.CommonName)
+}
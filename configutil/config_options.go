@@ -16,11 +16,14 @@ import (
 
 // ConfigOptions are options built for reading configs.
 type ConfigOptions struct {
-	Log       Logger
-	Context   context.Context
-	Contents  []ConfigContents
-	FilePaths []string
-	Env       env.Vars
+	Log        Logger
+	Context    context.Context
+	Contents   []ConfigContents
+	FilePaths  []string
+	MergePaths bool
+	Format     string
+	ExpandEnv  bool
+	Env        env.Vars
 }
 
 // ConfigContents are literal contents to read from.
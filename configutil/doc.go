@@ -41,5 +41,16 @@ If we want to also read from the environment, we can do so by adding a resolver,
 In the above, the environment variable `BIND_ADDR` takes precedence over the string value found in any configuration file(s).
 
 Note, we also "resolve" each of the attached configs first, in case they also have environment variables they read from etc.
+
+If the config should be checked once it's resolved, e.g. for combinations of values that are invalid, we can add a validator.
+
+    func (c Config) Validate() error {
+    	if c.Web.BindAddr == "" {
+    		return ex.New("web bind addr is required")
+    	}
+    	return nil
+    }
+
+`configutil.Read` calls `Validate` after `Resolve`, and returns the validation error.
 */
 package configutil // import "github.com/blend/go-sdk/configutil"
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/stringutil"
 )
 
 var (
@@ -36,9 +38,11 @@ type EnvVars struct {
 }
 
 // String returns a given environment variable as a string.
+//
+// An empty value is treated as unset.
 func (e EnvVars) String(ctx context.Context) (*string, error) {
 	vars := e.vars(ctx)
-	if vars.Has(e.Key) {
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
 		value := vars.String(e.Key)
 		return &value, nil
 	}
@@ -46,31 +50,40 @@ func (e EnvVars) String(ctx context.Context) (*string, error) {
 }
 
 // Strings returns a given environment variable as strings.
+//
+// An empty value is treated as unset.
 func (e EnvVars) Strings(ctx context.Context) ([]string, error) {
 	vars := e.vars(ctx)
 
-	if vars.Has(e.Key) {
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
 		return vars.CSV(e.Key), nil
 	}
 	return nil, nil
 }
 
 // Bool returns a given environment variable as a bool.
+//
+// An empty value is treated as unset, and a malformed value returns an error.
 func (e EnvVars) Bool(ctx context.Context) (*bool, error) {
 	vars := e.vars(ctx)
 
-	if vars.Has(e.Key) {
-		value := vars.Bool(e.Key)
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
+		value, err := stringutil.ParseBool(vars.String(e.Key))
+		if err != nil {
+			return nil, ex.New(err, ex.OptMessagef("var: %q", e.Key))
+		}
 		return &value, nil
 	}
 	return nil, nil
 }
 
 // Int returns a given environment variable as an int.
+//
+// An empty value is treated as unset.
 func (e EnvVars) Int(ctx context.Context) (*int, error) {
 	vars := e.vars(ctx)
 
-	if vars.Has(e.Key) {
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
 		value, err := vars.Int(e.Key)
 		if err != nil {
 			return nil, err
@@ -81,10 +94,12 @@ func (e EnvVars) Int(ctx context.Context) (*int, error) {
 }
 
 // Int32 returns a given environment variable as an int32.
+//
+// An empty value is treated as unset.
 func (e EnvVars) Int32(ctx context.Context) (*int32, error) {
 	vars := e.vars(ctx)
 
-	if vars.Has(e.Key) {
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
 		value, err := vars.Int32(e.Key)
 		if err != nil {
 			return nil, err
@@ -95,10 +110,12 @@ func (e EnvVars) Int32(ctx context.Context) (*int32, error) {
 }
 
 // Int64 returns a given environment variable as an int64.
+//
+// An empty value is treated as unset.
 func (e EnvVars) Int64(ctx context.Context) (*int64, error) {
 	vars := e.vars(ctx)
 
-	if vars.Has(e.Key) {
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
 		value, err := vars.Int64(e.Key)
 		if err != nil {
 			return nil, err
@@ -109,10 +126,12 @@ func (e EnvVars) Int64(ctx context.Context) (*int64, error) {
 }
 
 // Float64 returns a given environment variable as a float64.
+//
+// An empty value is treated as unset.
 func (e EnvVars) Float64(ctx context.Context) (*float64, error) {
 	vars := e.vars(ctx)
 
-	if vars.Has(e.Key) {
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
 		value, err := vars.Float64(e.Key)
 		if err != nil {
 			return nil, err
@@ -123,10 +142,13 @@ func (e EnvVars) Float64(ctx context.Context) (*float64, error) {
 }
 
 // Duration returns a given environment variable as a time.Duration.
+//
+// Values are parsed with `time.ParseDuration`, e.g. "30s" or "5m".
+// An empty value is treated as unset.
 func (e EnvVars) Duration(ctx context.Context) (*time.Duration, error) {
 	vars := e.vars(ctx)
 
-	if vars.Has(e.Key) {
+	if vars.Has(e.Key) && vars.String(e.Key) != "" {
 		value, err := vars.Duration(e.Key)
 		if err != nil {
 			return nil, err
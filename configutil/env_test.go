@@ -48,6 +48,11 @@ func TestEnv(t *testing.T) {
 	assert.NotNil(stringValue)
 	assert.Equal("foo", *stringValue)
 
+	ctx = createEnvVarsContext(key, "")
+	stringValue, err = Env(key).String(ctx)
+	assert.Nil(err)
+	assert.Nil(stringValue)
+
 	ctx = emptyEnvVarsContext()
 	stringsValue, err := Env(key).Strings(ctx)
 	assert.Nil(err)
@@ -59,6 +64,11 @@ func TestEnv(t *testing.T) {
 	assert.NotEmpty(stringsValue)
 	assert.Equal([]string{"foo", "bar"}, stringsValue)
 
+	ctx = createEnvVarsContext(key, "")
+	stringsValue, err = Env(key).Strings(ctx)
+	assert.Nil(err)
+	assert.Nil(stringsValue)
+
 	ctx = emptyEnvVarsContext()
 	boolValue, err := Env(key).Bool(ctx)
 	assert.Nil(err)
@@ -112,6 +122,11 @@ func TestEnv(t *testing.T) {
 	assert.NotNil(err)
 	assert.Nil(durationValue)
 
+	ctx = createEnvVarsContext(key, "")
+	durationValue, err = Env(key).Duration(ctx)
+	assert.Nil(err)
+	assert.Nil(durationValue)
+
 	ctx = createEnvVarsContext(key, "10s")
 	durationValue, err = Env(key).Duration(ctx)
 	assert.Nil(err)
@@ -19,6 +19,9 @@ const (
 
 	// ErrInvalidConfigExtension is a common error.
 	ErrInvalidConfigExtension = ex.Class("config extension invalid")
+
+	// ErrRequired is returned by the `Required` source if no prior source supplied a value.
+	ErrRequired = ex.Class("config value is required")
 )
 
 // IsIgnored returns if we should ignore the config read error.
@@ -52,6 +55,11 @@ func IsConfigPathUnset(err error) bool {
 	return ex.Is(err, ErrConfigPathUnset)
 }
 
+// IsRequired returns if an error is an ErrRequired.
+func IsRequired(err error) bool {
+	return ex.Is(err, ErrRequired)
+}
+
 // IsInvalidConfigExtension returns if an error is an ErrInvalidConfigExtension.
 func IsInvalidConfigExtension(err error) bool {
 	return ex.Is(err, ErrInvalidConfigExtension)
@@ -0,0 +1,69 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package configutil
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/blend/go-sdk/env"
+)
+
+// ExpandEnv expands environment variable references in a given string from a given set of vars.
+//
+// Both `$VAR` and `${VAR}` forms are supported, and `${VAR:-fallback}` yields `fallback`
+// if `VAR` is unset or empty. Unset variables without a fallback expand to the empty string.
+// A literal dollar sign can be written as `$$`; a `$` that isn't followed by a variable
+// name is left untouched.
+func ExpandEnv(s string, vars env.Vars) string {
+	return os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		if len(name) == 1 && !isEnvNameChar(name[0]) {
+			// shell special parameters (e.g. `$?` or `$1`) are left as is.
+			return "$" + name
+		}
+		if pieces := strings.SplitN(name, ":-", 2); len(pieces) == 2 {
+			if value := vars.Get(pieces[0]); value != "" {
+				return value
+			}
+			return pieces[1]
+		}
+		return vars.Get(name)
+	})
+}
+
+// expand returns a reader with environment variable references expanded
+// if `ExpandEnv` is set, otherwise it returns the reader unchanged.
+func (co ConfigOptions) expand(r io.Reader) io.Reader {
+	if !co.ExpandEnv {
+		return r
+	}
+	contents, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errReader{err}
+	}
+	return bytes.NewBufferString(ExpandEnv(string(contents), co.Env))
+}
+
+func isEnvNameChar(c uint8) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// errReader is a reader that returns an error on read.
+type errReader struct {
+	err error
+}
+
+func (er errReader) Read(_ []byte) (int, error) {
+	return 0, er.err
+}
@@ -0,0 +1,72 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package configutil
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+)
+
+func Test_ExpandEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := env.Vars{
+		"PASSWORD": "hunter2",
+		"EMPTY":    "",
+	}
+
+	testCases := [...]struct {
+		Input    string
+		Expected string
+	}{
+		{Input: "password: ${PASSWORD}", Expected: "password: hunter2"},
+		{Input: "password: $PASSWORD", Expected: "password: hunter2"},
+		{Input: "password: ${MISSING}", Expected: "password: "},
+		{Input: "host: ${MISSING:-localhost}", Expected: "host: localhost"},
+		{Input: "host: ${EMPTY:-localhost}", Expected: "host: localhost"},
+		{Input: "password: ${PASSWORD:-fallback}", Expected: "password: hunter2"},
+		{Input: "price: $$5", Expected: "price: $5"},
+		{Input: "literal: $${PASSWORD}", Expected: "literal: ${PASSWORD}"},
+		{Input: "cost: 5 $ each", Expected: "cost: 5 $ each"},
+		{Input: "args: $1 $?", Expected: "args: $1 $?"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(tc.Expected, ExpandEnv(tc.Input, vars), tc.Input)
+	}
+}
+
+func Test_Read_ExpandEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := env.Vars{
+		"SERVICE_ENV_VALUE": "expanded",
+	}
+
+	var cfg config
+	_, err := Read(&cfg,
+		OptUnsetPaths(),
+		OptEnv(vars),
+		OptExpandEnv(true),
+		OptAddContentString(ExtensionYAML, "env: ${SERVICE_ENV_VALUE}\nother: ${OTHER_VALUE:-fallback}\n"),
+	)
+	assert.Nil(err)
+	assert.Equal("expanded", cfg.Environment)
+	assert.Equal("fallback", cfg.Other)
+
+	var unexpanded config
+	_, err = Read(&unexpanded,
+		OptUnsetPaths(),
+		OptEnv(vars),
+		OptAddContentString(ExtensionYAML, "env: ${SERVICE_ENV_VALUE}\n"),
+	)
+	assert.Nil(err)
+	assert.Equal("${SERVICE_ENV_VALUE}", unexpanded.Environment)
+}
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
 )
 
 // TestMain is the testing entrypoint.
@@ -36,6 +37,18 @@ func (r *resolvedConfig) Resolve(ctx context.Context) error {
 	return nil
 }
 
+type validatedConfig struct {
+	resolvedConfig
+}
+
+// Validate implements configutil.Validator.
+func (v validatedConfig) Validate() error {
+	if v.Environment == "" {
+		return ex.New("environment is required")
+	}
+	return nil
+}
+
 type fullConfig struct {
 	fullConfigMeta `yaml:",inline"`
 
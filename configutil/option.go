@@ -111,3 +111,37 @@ func OptEnv(vars env.Vars) Option {
 		return nil
 	}
 }
+
+// OptMergePaths sets if the paths should be deep-merged before they're decoded into the config.
+//
+// Each existing path is decoded to a map in order, and later paths override keys
+// from earlier paths; nested maps are merged, and all other values (including slices)
+// are replaced. The merged result is then decoded into the config.
+func OptMergePaths(mergePaths bool) Option {
+	return func(co *ConfigOptions) error {
+		co.MergePaths = mergePaths
+		return nil
+	}
+}
+
+// OptFormat forces the format files are deserialized with, regardless of their extension.
+//
+// It should be one of the extension constants, e.g. `ExtensionJSON`. If unset,
+// the format is determined by each file's extension, defaulting to YAML.
+func OptFormat(ext string) Option {
+	return func(co *ConfigOptions) error {
+		co.Format = ext
+		return nil
+	}
+}
+
+// OptExpandEnv sets if environment variable references in config files and contents
+// should be expanded before they're deserialized.
+//
+// See `ExpandEnv` for the supported syntax.
+func OptExpandEnv(expandEnv bool) Option {
+	return func(co *ConfigOptions) error {
+		co.ExpandEnv = expandEnv
+		return nil
+	}
+}
@@ -8,6 +8,7 @@ Use of this source code is governed by a MIT license that can be found in the LI
 package configutil
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"os"
@@ -37,6 +38,10 @@ func MustRead(ref Any, options ...Option) (filePaths []string) {
 If the ref type is a `Resolver` the `Resolve(context.Context) error` method will
 be called on the ref and passed a context configured from the given options.
 
+If the ref type is a `Validator` the `Validate() error` method will be called on the ref
+after it's resolved, and the validation error (if any) is returned, e.g. to abort
+startup when the config has an invalid combination of values.
+
 By default, a well known set of paths will be read from (including a path read from the environment variable `CONFIG_PATH`).
 
 You can override this by providing options to specify which paths will be read from:
@@ -44,6 +49,20 @@ You can override this by providing options to specify which paths will be read f
 	paths, err := configutil.Read(&cfg, configutil.OptPaths("foo.yml"))
 
 The above will _only_ read from `foo.yml` to populate the `cfg` reference.
+
+Files are deserialized based on their extension; `.json` files are read as json, and
+`.yml`, `.yaml` or any other extension are read as yaml. You can force a format with `OptFormat`.
+
+If `OptExpandEnv(true)` is passed, environment variable references in the raw file contents
+are expanded before they're deserialized (see `ExpandEnv`):
+
+	password: ${DATABASE_PASSWORD}
+	host: ${DATABASE_HOST:-localhost}
+
+If `OptMergePaths(true)` is passed, every existing path is decoded to a map and deep-merged in order,
+with later paths overriding keys from earlier paths, before the result is decoded into the `cfg` reference:
+
+	paths, err := configutil.Read(&cfg, configutil.OptPaths("config.yml", "config.prod.yml"), configutil.OptMergePaths(true))
 */
 func Read(ref Any, options ...Option) (paths []string, err error) {
 	var configOptions ConfigOptions
@@ -54,7 +73,7 @@ func Read(ref Any, options ...Option) (paths []string, err error) {
 
 	for _, contents := range configOptions.Contents {
 		MaybeDebugf(configOptions.Log, "reading config contents with extension `%s`", contents.Ext)
-		err = deserialize(contents.Ext, contents.Contents, ref)
+		err = deserialize(contents.Ext, configOptions.expand(contents.Contents), ref)
 		if err != nil {
 			return
 		}
@@ -63,6 +82,8 @@ func Read(ref Any, options ...Option) (paths []string, err error) {
 	var f *os.File
 	var path string
 	var resolveErr error
+	var merged map[string]interface{}
+	mergedFormat := ExtensionJSON
 	for _, path = range configOptions.FilePaths {
 		if path == "" {
 			continue
@@ -79,7 +100,17 @@ func Read(ref Any, options ...Option) (paths []string, err error) {
 		defer f.Close()
 
 		MaybeDebugf(configOptions.Log, "reading config path: %s", path)
-		resolveErr = deserialize(filepath.Ext(path), f, ref)
+		format := pathFormat(configOptions.Format, path)
+		if configOptions.MergePaths {
+			var values map[string]interface{}
+			resolveErr = deserialize(format, configOptions.expand(f), &values)
+			if format != ExtensionJSON {
+				mergedFormat = ExtensionYAML
+			}
+			merged = mergeMaps(merged, values)
+		} else {
+			resolveErr = deserialize(format, configOptions.expand(f), ref)
+		}
 		if resolveErr != nil {
 			err = ex.New(resolveErr)
 			return
@@ -88,6 +119,14 @@ func Read(ref Any, options ...Option) (paths []string, err error) {
 		paths = append(paths, path)
 	}
 
+	if merged != nil {
+		MaybeDebugf(configOptions.Log, "reading merged config paths")
+		if resolveErr = deserializeMerged(mergedFormat, merged, ref); resolveErr != nil {
+			err = ex.New(resolveErr)
+			return
+		}
+	}
+
 	if typed, ok := ref.(Resolver); ok {
 		MaybeDebugf(configOptions.Log, "calling config resolver")
 		if resolveErr := typed.Resolve(configOptions.Background()); resolveErr != nil {
@@ -96,6 +135,15 @@ func Read(ref Any, options ...Option) (paths []string, err error) {
 			return
 		}
 	}
+
+	if typed, ok := ref.(Validator); ok {
+		MaybeDebugf(configOptions.Log, "calling config validator")
+		if validateErr := typed.Validate(); validateErr != nil {
+			MaybeErrorf(configOptions.Log, "calling validator error: %+v", validateErr)
+			err = validateErr
+			return
+		}
+	}
 	return
 }
 
@@ -113,6 +161,60 @@ func createConfigOptions(options ...Option) (configOptions ConfigOptions, err er
 	return
 }
 
+// deserializeMerged re-encodes merged path values in a given format
+// and deserializes them into a config.
+//
+// The values are encoded as json only if every merged path was json, so that
+// the struct tags for the format the paths were written in are used.
+func deserializeMerged(format string, merged map[string]interface{}, ref Any) error {
+	buffer := new(bytes.Buffer)
+	var err error
+	if format == ExtensionJSON {
+		err = json.NewEncoder(buffer).Encode(merged)
+	} else {
+		err = yaml.NewEncoder(buffer).Encode(merged)
+	}
+	if err != nil {
+		return ex.New(err)
+	}
+	return deserialize(format, buffer, ref)
+}
+
+// mergeMaps deep merges the values of a given src map into a given dst map.
+//
+// Nested maps are merged recursively, and any other values in src,
+// including slices, replace the values in dst.
+func mergeMaps(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{})
+	}
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[key] = mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcValue
+	}
+	return dst
+}
+
+// pathFormat returns the format to deserialize a given path with.
+//
+// Paths with an unknown extension are treated as yaml.
+func pathFormat(format, path string) string {
+	if format != "" {
+		return format
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ExtensionJSON, ExtensionYAML, ExtensionYML:
+		return ext
+	default:
+		return ExtensionYAML
+	}
+}
+
 // deserialize deserializes a config.
 func deserialize(ext string, r io.Reader, ref Any) error {
 	// make sure the extension starts with a "."
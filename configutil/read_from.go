@@ -0,0 +1,59 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package configutil
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ReadFromReader reads a config from a given reader.
+//
+// It is functionally equivalent to `ReadFromBytes` with the full contents of the reader.
+func ReadFromReader(ref Any, r io.Reader, options ...Option) error {
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return ex.New(err)
+	}
+	return ReadFromBytes(ref, contents, options...)
+}
+
+// ReadFromBytes reads a config from a given set of serialized contents.
+//
+// It uses the same decoding as `Read`, but the default paths are not read from.
+// The contents are deserialized with the format set by `OptFormat`, otherwise
+// contents that start with a '{' are read as json and anything else is read as yaml.
+//
+// Options such as `OptExpandEnv`, `OptEnv` and `OptContext` apply as they do for `Read`,
+// including calling the `Resolve(context.Context) error` method if the ref is a `Resolver`
+// and the `Validate() error` method if the ref is a `Validator`.
+func ReadFromBytes(ref Any, contents []byte, options ...Option) error {
+	options = append([]Option{OptUnsetPaths()}, options...)
+	options = append(options, func(co *ConfigOptions) error {
+		co.Contents = append(co.Contents, ConfigContents{
+			Ext:      contentsFormat(co.Format, contents),
+			Contents: bytes.NewReader(contents),
+		})
+		return nil
+	})
+	_, err := Read(ref, options...)
+	return err
+}
+
+// contentsFormat returns the format to deserialize a given set of contents with.
+func contentsFormat(format string, contents []byte) string {
+	if format != "" {
+		return format
+	}
+	if trimmed := bytes.TrimSpace(contents); len(trimmed) > 0 && trimmed[0] == '{' {
+		return ExtensionJSON
+	}
+	return ExtensionYAML
+}
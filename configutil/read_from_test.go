@@ -0,0 +1,88 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package configutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestReadFromBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	assert.Nil(ReadFromBytes(&cfg, []byte("env: test_yaml\nother: foo\n")))
+	assert.Equal("test_yaml", cfg.Environment)
+	assert.Equal("foo", cfg.Other)
+
+	cfg = config{}
+	assert.Nil(ReadFromBytes(&cfg, []byte(` { "env": "test_json", "other": "moo" }`)))
+	assert.Equal("test_json", cfg.Environment)
+	assert.Equal("moo", cfg.Other)
+
+	cfg = config{}
+	assert.Nil(ReadFromBytes(&cfg, []byte(`{"env": "test_format"}`), OptFormat(ExtensionYAML)))
+	assert.Equal("test_format", cfg.Environment)
+
+	cfg = config{}
+	err := ReadFromBytes(&cfg, []byte(`env: test`), OptFormat(ExtensionJSON))
+	assert.NotNil(err)
+
+	cfg = config{}
+	assert.NotNil(ReadFromBytes(&cfg, []byte("env: [unterminated")))
+}
+
+func TestReadFromBytesIgnoresDefaultPaths(t *testing.T) {
+	assert := assert.New(t)
+	defer env.Restore()
+	env.Env().Set(EnvVarConfigPath, "testdata/config.yaml")
+
+	var cfg config
+	assert.Nil(ReadFromBytes(&cfg, []byte("other: from_bytes")))
+	assert.Empty(cfg.Environment)
+	assert.Equal("from_bytes", cfg.Other)
+}
+
+func TestReadFromBytesOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	assert.Nil(ReadFromBytes(&cfg,
+		[]byte("other: ${OTHER_VALUE}"),
+		OptExpandEnv(true),
+		OptEnv(env.Vars{"OTHER_VALUE": "expanded"}),
+	))
+	assert.Equal("expanded", cfg.Other)
+
+	var resolved resolvedConfig
+	assert.Nil(ReadFromBytes(&resolved, []byte("{}"), OptEnv(env.Vars{"ENVIRONMENT": "resolved"})))
+	assert.Equal("resolved", resolved.Environment)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(_ []byte) (int, error) {
+	return 0, ex.New("this is only a test")
+}
+
+func TestReadFromReader(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	assert.Nil(ReadFromReader(&cfg, strings.NewReader("env: test_reader\nother: bar\n")))
+	assert.Equal("test_reader", cfg.Environment)
+	assert.Equal("bar", cfg.Other)
+
+	err := ReadFromReader(&cfg, failingReader{})
+	assert.NotNil(err)
+	assert.Equal("this is only a test", ex.ErrClass(err).Error())
+}
@@ -155,6 +155,26 @@ func TestReadResolver(t *testing.T) {
 	assert.Equal("resolved", cfg.Environment)
 }
 
+func TestReadValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg validatedConfig
+	_, err := Read(&cfg,
+		OptPaths(""),
+		OptEnv(env.Vars{"ENVIRONMENT": "resolved"}),
+	)
+	assert.Nil(err)
+	assert.Equal("resolved", cfg.Environment)
+
+	cfg = validatedConfig{}
+	_, err = Read(&cfg,
+		OptPaths(""),
+		OptEnv(env.Vars{}),
+	)
+	assert.NotNil(err)
+	assert.Equal("environment is required", ex.ErrClass(err).Error())
+}
+
 func TestRead_multiple(t *testing.T) {
 	assert := assert.New(t)
 
@@ -216,3 +236,96 @@ child:
 	assert.Equal("child-field2-contents2", cfg.Child.Field2)
 	assert.Equal("child-field3-contents1", cfg.Child.Field3)
 }
+
+func TestReadUnknownExtension(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	paths, err := Read(&cfg, OptPaths("testdata/config"))
+	assert.Nil(err)
+	assert.Equal([]string{"testdata/config"}, paths)
+	assert.Equal("test_noext", cfg.Environment)
+	assert.Equal("bar", cfg.Other)
+}
+
+func TestReadFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	paths, err := Read(&cfg, OptPaths("testdata/config.conf"), OptFormat(ExtensionJSON))
+	assert.Nil(err)
+	assert.Equal([]string{"testdata/config.conf"}, paths)
+	assert.Equal("test_forced", cfg.Environment)
+	assert.Equal("baz", cfg.Other)
+}
+
+type mergeConfig struct {
+	Environment string   `json:"env" yaml:"env"`
+	Other       string   `json:"other" yaml:"other"`
+	Hosts       []string `json:"hosts" yaml:"hosts"`
+	Database    struct {
+		Host    string            `json:"host" yaml:"host"`
+		Port    int               `json:"port" yaml:"port"`
+		Options map[string]string `json:"options" yaml:"options"`
+	} `json:"database" yaml:"database"`
+}
+
+func TestReadMergePaths(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg mergeConfig
+	paths, err := Read(&cfg,
+		OptPaths("testdata/merge_base.yml", "testdata/not_a_file.yml", "testdata/merge_prod.yml"),
+		OptMergePaths(true),
+	)
+	assert.Nil(err)
+	assert.Equal([]string{"testdata/merge_base.yml", "testdata/merge_prod.yml"}, paths)
+	assert.Equal("prod", cfg.Environment)
+	assert.Equal("base-other", cfg.Other)
+	assert.Equal([]string{"prod-a"}, cfg.Hosts)
+	assert.Equal("db.prod", cfg.Database.Host)
+	assert.Equal(5432, cfg.Database.Port)
+	assert.Equal(map[string]string{"sslmode": "require", "timeout": "5s"}, cfg.Database.Options)
+}
+
+func TestReadMergePathsJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	paths, err := Read(&cfg,
+		OptPaths("testdata/project.yml", "testdata/config.json"),
+		OptMergePaths(true),
+	)
+	assert.Nil(err)
+	assert.Equal([]string{"testdata/project.yml", "testdata/config.json"}, paths)
+	assert.Equal("test_json", cfg.Environment)
+	assert.Equal("moo", cfg.Other)
+	assert.Equal("project-base", cfg.Base)
+}
+
+func TestMergeMaps(t *testing.T) {
+	assert := assert.New(t)
+
+	merged := mergeMaps(nil, map[string]interface{}{
+		"foo": "bar",
+		"nested": map[string]interface{}{
+			"a": 1,
+			"b": 2,
+		},
+		"list": []interface{}{"a", "b"},
+	})
+	merged = mergeMaps(merged, map[string]interface{}{
+		"nested": map[string]interface{}{
+			"b": 3,
+		},
+		"list": []interface{}{"c"},
+	})
+	assert.Equal(map[string]interface{}{
+		"foo": "bar",
+		"nested": map[string]interface{}{
+			"a": 1,
+			"b": 3,
+		},
+		"list": []interface{}{"c"},
+	}, merged)
+}
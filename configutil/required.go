@@ -0,0 +1,88 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package configutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+var (
+	_ StringSource   = (*RequiredSource)(nil)
+	_ StringsSource  = (*RequiredSource)(nil)
+	_ BoolSource     = (*RequiredSource)(nil)
+	_ IntSource      = (*RequiredSource)(nil)
+	_ Int32Source    = (*RequiredSource)(nil)
+	_ Int64Source    = (*RequiredSource)(nil)
+	_ Float64Source  = (*RequiredSource)(nil)
+	_ DurationSource = (*RequiredSource)(nil)
+)
+
+// Required returns a source that returns an error naming the field.
+//
+// It should be passed as the last source to a `Set...` resolver, so that
+// resolution fails if no prior source supplied a value (empty environment
+// variables count as unset):
+//
+//    configutil.SetString(&c.DatabaseURL, configutil.Env("DATABASE_URL"), configutil.String(c.DatabaseURL), configutil.Required("DatabaseURL"))
+//
+// It can be used with *any* config.Set___ type.
+func Required(fieldName string) RequiredSource {
+	return RequiredSource{FieldName: fieldName}
+}
+
+// RequiredSource is a value source that always returns an `ErrRequired` error.
+type RequiredSource struct {
+	FieldName string
+}
+
+// String implements StringSource.
+func (r RequiredSource) String(_ context.Context) (*string, error) {
+	return nil, r.err()
+}
+
+// Strings implements StringsSource.
+func (r RequiredSource) Strings(_ context.Context) ([]string, error) {
+	return nil, r.err()
+}
+
+// Bool implements BoolSource.
+func (r RequiredSource) Bool(_ context.Context) (*bool, error) {
+	return nil, r.err()
+}
+
+// Int implements IntSource.
+func (r RequiredSource) Int(_ context.Context) (*int, error) {
+	return nil, r.err()
+}
+
+// Int32 implements Int32Source.
+func (r RequiredSource) Int32(_ context.Context) (*int32, error) {
+	return nil, r.err()
+}
+
+// Int64 implements Int64Source.
+func (r RequiredSource) Int64(_ context.Context) (*int64, error) {
+	return nil, r.err()
+}
+
+// Float64 implements Float64Source.
+func (r RequiredSource) Float64(_ context.Context) (*float64, error) {
+	return nil, r.err()
+}
+
+// Duration implements DurationSource.
+func (r RequiredSource) Duration(_ context.Context) (*time.Duration, error) {
+	return nil, r.err()
+}
+
+func (r RequiredSource) err() error {
+	return ex.New(ErrRequired, ex.OptMessagef("field: %s", r.FieldName))
+}
@@ -0,0 +1,60 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package configutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestRequired(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := ConfigOptions{
+		Env: env.Vars{"DATABASE_URL": "postgres://localhost/test"},
+	}.Background()
+
+	var databaseURL string
+	assert.Nil(Resolve(ctx,
+		SetString(&databaseURL, Env("DATABASE_URL"), Required("DatabaseURL")),
+	))
+	assert.Equal("postgres://localhost/test", databaseURL)
+
+	var missing string
+	err := Resolve(ctx,
+		SetString(&missing, Env("MISSING"), String(""), Required("Missing")),
+	)
+	assert.True(IsRequired(err))
+	assert.Equal("field: Missing", ex.ErrMessage(err))
+
+	emptyCtx := ConfigOptions{
+		Env: env.Vars{"DATABASE_URL": ""},
+	}.Background()
+	var emptyDatabaseURL string
+	err = Resolve(emptyCtx,
+		SetString(&emptyDatabaseURL, Env("DATABASE_URL"), Required("DatabaseURL")),
+	)
+	assert.True(IsRequired(err))
+	assert.Equal("field: DatabaseURL", ex.ErrMessage(err))
+	var emptyHosts []string
+	assert.True(IsRequired(SetStrings(&emptyHosts, Env("DATABASE_URL"), Required("Hosts"))(emptyCtx)))
+
+	var timeout time.Duration
+	assert.True(IsRequired(SetDuration(&timeout, Required("Timeout"))(context.TODO())))
+	var port int
+	assert.True(IsRequired(SetInt(&port, Required("Port"))(context.TODO())))
+	var enabled bool
+	assert.True(IsRequired(SetBool(&enabled, Required("Enabled"))(context.TODO())))
+	var hosts []string
+	assert.True(IsRequired(SetStrings(&hosts, Required("Hosts"))(context.TODO())))
+}
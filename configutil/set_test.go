@@ -13,6 +13,10 @@ import (
 	"time"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/ref"
+	"github.com/blend/go-sdk/stringutil"
 )
 
 func TestSetString(t *testing.T) {
@@ -151,7 +155,63 @@ func TestSetDuration(t *testing.T) {
 	assert.Equal(time.Second, value)
 
 	errors := Parse(String("bad"))
-	assert.NotNil(SetDuration(&value, errors))
+	assert.NotNil(SetDuration(&value, errors)(context.TODO()))
+}
+
+func TestSetDurationEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := ConfigOptions{
+		Env: env.Vars{"EMPTY": "", "TIMEOUT": "5m", "BAD": "five minutes"},
+	}.Background()
+
+	var value time.Duration
+	assert.Nil(Resolve(ctx,
+		SetDuration(&value, Env("UNSET"), Env("EMPTY"), Env("TIMEOUT"), Duration(time.Second)),
+	))
+	assert.Equal(5*time.Minute, value)
+
+	err := Resolve(ctx, SetDuration(&value, Env("BAD"), Duration(time.Second)))
+	assert.NotNil(err)
+	assert.Contains(ex.ErrMessage(err), "BAD")
+}
+
+func TestSetEnvTyped(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := ConfigOptions{
+		Env: env.Vars{
+			"EMPTY":   "",
+			"INT":     "1234",
+			"BOOL":    "yes",
+			"FLOAT":   "3.14",
+			"MALFORM": "not-a-value",
+		},
+	}.Background()
+
+	var intValue int
+	var boolValue bool
+	var floatValue float64
+	assert.Nil(Resolve(ctx,
+		SetInt(&intValue, Env("EMPTY"), Env("INT"), Int(5)),
+		SetBool(&boolValue, Env("EMPTY"), Env("BOOL"), Bool(ref.Bool(false))),
+		SetFloat64(&floatValue, Env("EMPTY"), Env("FLOAT"), Float64(1.0)),
+	))
+	assert.Equal(1234, intValue)
+	assert.True(boolValue)
+	assert.Equal(3.14, floatValue)
+
+	err := Resolve(ctx, SetInt(&intValue, Env("MALFORM")))
+	assert.NotNil(err)
+	assert.Contains(ex.ErrMessage(err), "MALFORM")
+
+	err = Resolve(ctx, SetBool(&boolValue, Env("MALFORM")))
+	assert.True(ex.Is(err, stringutil.ErrInvalidBoolValue))
+	assert.Contains(ex.ErrMessage(err), "MALFORM")
+
+	err = Resolve(ctx, SetFloat64(&floatValue, Env("MALFORM")))
+	assert.NotNil(err)
+	assert.Contains(ex.ErrMessage(err), "MALFORM")
 }
 
 func TestSetDurationPtr(t *testing.T) {
@@ -166,5 +226,5 @@ func TestSetDurationPtr(t *testing.T) {
 	assert.Equal(time.Second, *value)
 
 	errors := Parse(String("bad"))
-	assert.NotNil(SetDurationPtr(&value, errors))
+	assert.NotNil(SetDurationPtr(&value, errors)(context.TODO()))
 }
@@ -0,0 +1,15 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package configutil
+
+// Validator is a type that can be validated.
+//
+// `Read` calls `Validate` after `Resolve` (if the type is also a `Resolver`).
+type Validator interface {
+	Validate() error
+}
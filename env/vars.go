@@ -283,7 +283,11 @@ func (ev Vars) MustFloat64(envVar string, defaults ...float64) float64 {
 // Duration returns a duration value for a given key.
 func (ev Vars) Duration(envVar string, defaults ...time.Duration) (time.Duration, error) {
 	if value, hasValue := ev[envVar]; hasValue {
-		return time.ParseDuration(value)
+		parsedValue, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, ex.New(err, ex.OptMessagef("var: %q", envVar))
+		}
+		return parsedValue, nil
 	}
 	for _, defaultValue := range defaults {
 		if defaultValue > 0 {
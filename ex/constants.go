@@ -12,3 +12,6 @@ const (
 	DefaultStartDepth    = 3
 	DefaultNewStartDepth = 4
 )
+
+// DefaultMaxStackDepth is the default maximum number of frames captured for a stack trace.
+const DefaultMaxStackDepth = 32
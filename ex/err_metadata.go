@@ -0,0 +1,48 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package ex
+
+import "errors"
+
+// ErrMetadata returns the metadata of an exception merged with the metadata of its inner errors.
+// If the same key is set at multiple levels, the outermost value wins.
+// If no errors in the chain have metadata, this will return nil.
+func ErrMetadata(err interface{}) map[string]interface{} {
+	var chain []*Ex
+	for cause := err; cause != nil; {
+		if typed, ok := cause.(*Ex); ok && typed == nil {
+			break
+		}
+		if typed := As(cause); typed != nil {
+			chain = append(chain, typed)
+			cause = typed.Inner
+			continue
+		}
+		typed, ok := cause.(error)
+		if !ok {
+			break
+		}
+		// follow errors that wrap exceptions, e.g. with `fmt.Errorf("%w", err)`.
+		if inner := errors.Unwrap(typed); inner != nil {
+			cause = inner
+			continue
+		}
+		break
+	}
+
+	var output map[string]interface{}
+	for index := len(chain) - 1; index >= 0; index-- {
+		for key, value := range chain[index].Metadata {
+			if output == nil {
+				output = make(map[string]interface{})
+			}
+			output[key] = value
+		}
+	}
+	return output
+}
@@ -40,31 +40,40 @@ func NewWithStackDepth(class interface{}, startDepth int, options ...Option) Exc
 		if typed == nil {
 			return nil
 		}
-		ex = typed
+		for _, option := range options {
+			option(typed)
+		}
+		return typed
 	case error:
 		if typed == nil {
 			return nil
 		}
-
 		ex = &Ex{
-			Class:      typed,
-			Inner:      errors.Unwrap(typed),
-			StackTrace: Callers(startDepth),
+			Class: typed,
+			Inner: errors.Unwrap(typed),
 		}
 	case string:
 		ex = &Ex{
-			Class:      Class(typed),
-			StackTrace: Callers(startDepth),
+			Class: Class(typed),
 		}
 	default:
 		ex = &Ex{
-			Class:      Class(fmt.Sprint(class)),
-			StackTrace: Callers(startDepth),
+			Class: Class(fmt.Sprint(class)),
 		}
 	}
+
+	// options are applied before the stack is captured so that
+	// `OptStackDepth` and `OptSkipStack` can avoid the work entirely.
 	for _, option := range options {
 		option(ex)
 	}
+	if ex.StackTrace == nil && !ex.skipStack {
+		maxDepth := MaxStackDepth
+		if ex.stackDepth > 0 {
+			maxDepth = ex.stackDepth
+		}
+		ex.StackTrace = CallersWithDepth(startDepth, maxDepth)
+	}
 	return ex
 }
 
@@ -79,6 +88,13 @@ type Ex struct {
 	Inner error
 	// StackTrace is the call stack frames used to create the stack output.
 	StackTrace StackTrace
+	// Metadata holds structured key/value annotations, see `OptMetadata` and `ErrMetadata`.
+	Metadata map[string]interface{}
+
+	// stackDepth optionally caps the number of frames captured when the exception is created.
+	stackDepth int
+	// skipStack disables capturing the stack when the exception is created.
+	skipStack bool
 }
 
 // WithMessage sets the exception message.
@@ -161,6 +177,9 @@ func (e *Ex) Decompose() map[string]interface{} {
 	if e.StackTrace != nil {
 		values["StackTrace"] = e.StackTrace.Strings()
 	}
+	if len(e.Metadata) > 0 {
+		values["Metadata"] = e.Metadata
+	}
 	if e.Inner != nil {
 		if typed, isTyped := e.Inner.(*Ex); isTyped {
 			values["Inner"] = typed.Decompose()
@@ -172,8 +191,42 @@ func (e *Ex) Decompose() map[string]interface{} {
 }
 
 // MarshalJSON is a custom json marshaler.
+//
+// It emits a machine readable form of the exception:
+//
+//	{"class": "...", "message": "...", "metadata": {...}, "inner": {...}, "stack": [{"file": "...", "func": "...", "line": 0}]}
+//
+// Empty fields are omitted.
 func (e *Ex) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Decompose())
+	return json.Marshal(e.marshalable())
+}
+
+// marshalableEx is the json representation of an exception.
+type marshalableEx struct {
+	Class    string                 `json:"class,omitempty"`
+	Message  string                 `json:"message,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Inner    *marshalableEx         `json:"inner,omitempty"`
+	Stack    []StackFrame           `json:"stack,omitempty"`
+}
+
+func (e *Ex) marshalable() *marshalableEx {
+	output := &marshalableEx{
+		Message:  e.Message,
+		Metadata: e.Metadata,
+		Stack:    StackFrames(e.StackTrace),
+	}
+	if e.Class != nil {
+		output.Class = e.Class.Error()
+	}
+	if e.Inner != nil {
+		if typed, isTyped := e.Inner.(*Ex); isTyped && typed != nil {
+			output.Inner = typed.marshalable()
+		} else {
+			output.Inner = &marshalableEx{Class: e.Inner.Error()}
+		}
+	}
+	return output
 }
 
 // UnmarshalJSON is a custom json unmarshaler.
@@ -191,6 +244,20 @@ func (e *Ex) UnmarshalJSON(contents []byte) error {
 		return New(err)
 	}
 
+	// normalize the machine readable keys emitted by `MarshalJSON`.
+	for from, to := range map[string]string{"class": "Class", "message": "Message", "metadata": "Metadata", "inner": "Inner"} {
+		if value, ok := values[from]; ok {
+			values[to] = value
+		}
+	}
+	if stack, ok := values["stack"]; ok {
+		var frames []StackFrame
+		if err := json.Unmarshal([]byte(stack), &frames); err != nil {
+			return New(err)
+		}
+		e.StackTrace = StackFramesToStrings(frames)
+	}
+
 	if class, ok := values["Class"]; ok {
 		var classString string
 		if err := json.Unmarshal([]byte(class), &classString); err != nil {
@@ -205,6 +272,12 @@ func (e *Ex) UnmarshalJSON(contents []byte) error {
 		}
 	}
 
+	if metadata, ok := values["Metadata"]; ok {
+		if err := json.Unmarshal([]byte(metadata), &e.Metadata); err != nil {
+			return New(err)
+		}
+	}
+
 	if inner, ok := values["Inner"]; ok {
 		var innerClass string
 		if tryErr := json.Unmarshal([]byte(inner), &class); tryErr == nil {
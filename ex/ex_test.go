@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
@@ -131,10 +132,10 @@ func TestExceptionFormatters(t *testing.T) {
 func TestMarshalJSON(t *testing.T) {
 
 	type ReadableStackTrace struct {
-		Class   string   `json:"Class"`
-		Message string   `json:"Message"`
-		Inner   error    `json:"Inner"`
-		Stack   []string `json:"StackTrace"`
+		Class   string       `json:"class"`
+		Message string       `json:"message"`
+		Inner   interface{}  `json:"inner"`
+		Stack   []StackFrame `json:"stack"`
 	}
 
 	a := assert.New(t)
@@ -176,6 +177,39 @@ func TestMarshalJSON(t *testing.T) {
 	a.Equal(message, ex2.Class)
 }
 
+func TestMarshalJSONFields(t *testing.T) {
+	assert := assert.New(t)
+
+	contents, err := json.Marshal(New("this is a test", OptSkipStack()))
+	assert.Nil(err)
+	assert.Equal(`{"class":"this is a test"}`, string(contents))
+
+	contents, err = json.Marshal(New("this is a test",
+		OptMessage("test message"),
+		OptInnerClass(fmt.Errorf("inner error")),
+		OptStackTrace(StackStrings([]string{"github.com/blend/go-sdk/ex.Foo\n\t/go/src/ex/foo.go:12", "unparsed"})),
+	))
+	assert.Nil(err)
+	assert.Equal(`{"class":"this is a test","message":"test message","inner":{"class":"inner error"},"stack":[{"file":"/go/src/ex/foo.go","func":"github.com/blend/go-sdk/ex.Foo","line":12},{"file":"unparsed"}]}`, string(contents))
+
+	contents, err = json.Marshal(New("this is a test"))
+	assert.Nil(err)
+	var output struct {
+		Stack []StackFrame `json:"stack"`
+	}
+	assert.Nil(json.Unmarshal(contents, &output))
+	assert.NotEmpty(output.Stack)
+	assert.Equal("github.com/blend/go-sdk/ex.TestMarshalJSONFields", output.Stack[0].Func)
+	assert.HasSuffix(output.Stack[0].File, "ex_test.go")
+	assert.NotZero(output.Stack[0].Line)
+
+	var verify Ex
+	assert.Nil(json.Unmarshal(contents, &verify))
+	assert.NotNil(verify.StackTrace)
+	assert.Len(verify.StackTrace.Strings(), len(output.Stack))
+	assert.HasPrefix(verify.StackTrace.Strings()[0], "github.com/blend/go-sdk/ex.TestMarshalJSONFields\n\t")
+}
+
 func TestJSON(t *testing.T) {
 	assert := assert.New(t)
 
@@ -302,6 +336,17 @@ func TestException_ErrorsIsCompatability(t *testing.T) {
 		assert.True(errors.Is(fourthErr, Class("ex err")))
 	}
 
+	{ // Sentinel error wrapped twice
+		firstErr := New(io.EOF)
+		secondErr := New("read failed", OptInner(firstErr))
+		thirdErr := fmt.Errorf("handler: %w", secondErr)
+
+		assert.True(errors.Is(firstErr, io.EOF))
+		assert.True(errors.Is(secondErr, io.EOF))
+		assert.True(errors.Is(thirdErr, io.EOF))
+		assert.False(errors.Is(thirdErr, io.ErrUnexpectedEOF))
+	}
+
 	{ // Target is nested in an Ex class and not in Inner chain
 		firstErr := errors.New("inner most")
 		secondErr := fmt.Errorf("standard err: %w", firstErr)
@@ -342,6 +387,16 @@ func TestException_ErrorsAsCompatability(t *testing.T) {
 		assert.Equal("inner most", matchedErr.value)
 	}
 
+	{ // Sentinel error wrapped twice, targeting Ex
+		innerErr := New(io.EOF)
+		outerErr := fmt.Errorf("handler: %w", New("read failed", OptInner(innerErr)))
+
+		var matchedErr *Ex
+		assert.True(errors.As(outerErr, &matchedErr))
+		assert.Equal("read failed", matchedErr.Class.Error())
+		assert.Equal(innerErr, matchedErr.Unwrap())
+	}
+
 	{ // Triple Nesting, targeting non-Ex
 		firstErr := structuredError{"inner most"}
 		secondErr := fmt.Errorf("standard err: %w", firstErr)
@@ -353,3 +408,42 @@ func TestException_ErrorsAsCompatability(t *testing.T) {
 		assert.Equal("inner most", matchedErr.value)
 	}
 }
+
+func TestErrMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(ErrMetadata(nil))
+	assert.Nil(ErrMetadata(fmt.Errorf("not an exception")))
+	assert.Nil(ErrMetadata(New("no metadata")))
+	assert.Nil(ErrMetadata((*Ex)(nil)))
+
+	inner := New("inner", OptMetadata("user_id", 42), OptMetadata("attempt", 1))
+	wrapped := fmt.Errorf("wrapped: %w", inner)
+	outer := New("outer", OptMetadata("attempt", 3), OptMetadata("path", "/foo"), OptInner(wrapped))
+
+	assert.Equal(map[string]interface{}{
+		"user_id": 42,
+		"attempt": 3,
+		"path":    "/foo",
+	}, ErrMetadata(outer))
+	assert.Equal(map[string]interface{}{"user_id": 42, "attempt": 1}, ErrMetadata(wrapped))
+
+	// the merged metadata is a copy.
+	ErrMetadata(outer)["user_id"] = 0
+	assert.Equal(42, As(inner).Metadata["user_id"])
+}
+
+func TestMarshalJSONMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	contents, err := json.Marshal(New("this is a test", OptSkipStack(), OptMetadata("user_id", 42)))
+	assert.Nil(err)
+	assert.Equal(`{"class":"this is a test","metadata":{"user_id":42}}`, string(contents))
+
+	var verify Ex
+	assert.Nil(json.Unmarshal(contents, &verify))
+	assert.Equal(map[string]interface{}{"user_id": float64(42)}, verify.Metadata)
+
+	decomposed := As(New("this is a test", OptMetadata("user_id", 42))).Decompose()
+	assert.Equal(map[string]interface{}{"user_id": 42}, decomposed["Metadata"])
+}
@@ -7,7 +7,10 @@ Use of this source code is governed by a MIT license that can be found in the LI
 
 package ex
 
-import "errors"
+import (
+	"errors"
+	"reflect"
+)
 
 // Is is a helper function that returns if an error is an ex.
 //
@@ -25,12 +28,12 @@ func Is(err interface{}, cause error) bool {
 			if causeTyped.Class == nil {
 				return false
 			}
-			return typed.Class == causeTyped.Class
+			return equal(typed.Class, causeTyped.Class)
 		}
-		return (typed.Class == cause) || (typed.Class.Error() == cause.Error()) || errors.Is(typed.Class, cause)
+		return equal(typed.Class, cause) || (typed.Class.Error() == cause.Error()) || errors.Is(typed.Class, cause)
 	}
 	if typed, ok := err.(ClassProvider); ok {
-		return typed.Class() == cause || (typed.Class().Error() == cause.Error()) || errors.Is(typed.Class(), cause)
+		return equal(typed.Class(), cause) || (typed.Class().Error() == cause.Error()) || errors.Is(typed.Class(), cause)
 	}
 
 	// handle the case of multi-exceptions
@@ -45,8 +48,22 @@ func Is(err interface{}, cause error) bool {
 
 	// handle regular errors
 	if typed, ok := err.(error); ok && typed != nil {
-		return (err == cause) || (typed.Error() == cause.Error()) || errors.Is(typed, cause)
+		return equal(err, cause) || (typed.Error() == cause.Error()) || errors.Is(typed, cause)
 	}
 	// handle ???
-	return err == cause
+	return equal(err, cause)
+}
+
+// equal returns if two values are equal with `==`.
+//
+// It returns false if either value has a type that is not comparable, e.g. the
+// `Multi` class of an exception returned by `Join`, where `==` would panic.
+func equal(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !reflect.TypeOf(a).Comparable() || !reflect.TypeOf(b).Comparable() {
+		return false
+	}
+	return a == b
 }
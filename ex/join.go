@@ -0,0 +1,35 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package ex
+
+// Join aggregates a given set of errors into a single error.
+//
+// It returns nil if every error is nil, and the single error (with a stack
+// captured at `Join` if it doesn't already have one) if only one error is non-nil.
+// Otherwise it returns an exception with a `Multi` class holding the non-nil errors,
+// with a stack captured at `Join`. The aggregate's `Error()` lists each error, and
+// `errors.Is` and `errors.As` match against any of the errors.
+func Join(errs ...error) error {
+	var nonNil Multi
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return NewWithStackDepth(nonNil[0], DefaultNewStartDepth)
+	default:
+		return &Ex{
+			Class:      nonNil,
+			StackTrace: Callers(DefaultStartDepth),
+		}
+	}
+}
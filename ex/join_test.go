@@ -0,0 +1,71 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package ex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestJoinIsJoined(t *testing.T) {
+	assert := assert.New(t)
+
+	a := Join(io.EOF, fmt.Errorf("a"))
+	b := Join(io.ErrUnexpectedEOF, fmt.Errorf("b"))
+
+	assert.NotPanic(func() {
+		assert.False(errors.Is(a, b))
+		assert.False(Is(a, b))
+		assert.False(Is(Multi{io.EOF}, Multi{io.EOF}))
+	})
+	assert.True(errors.Is(a, a))
+	assert.True(errors.Is(a, io.EOF))
+	assert.True(Is(b, io.ErrUnexpectedEOF))
+}
+
+func TestJoin(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Join())
+	assert.Nil(Join(nil, nil))
+
+	single := Join(nil, io.EOF, nil)
+	assert.NotNil(single)
+	assert.True(errors.Is(single, io.EOF))
+	assert.NotNil(As(single).StackTrace)
+
+	existing := New("existing")
+	assert.Equal(existing, Join(existing, nil))
+
+	joined := Join(Class("first"), nil, fmt.Errorf("second: %w", io.EOF), New(structuredError{"third"}))
+	assert.NotNil(joined)
+	assert.True(strings.HasPrefix(joined.Error(), "3 errors occurred:"), joined.Error())
+	assert.Contains(joined.Error(), "* first")
+	assert.Contains(joined.Error(), "* second: EOF")
+	assert.Contains(joined.Error(), "* third")
+	assert.Len(Unwrap(joined), 3)
+
+	typed := As(joined)
+	assert.NotNil(typed)
+	assert.NotNil(typed.StackTrace)
+	assert.Contains(fmt.Sprintf("%+v", joined), "TestJoin")
+
+	assert.True(errors.Is(joined, Class("first")))
+	assert.True(errors.Is(joined, io.EOF))
+	assert.False(errors.Is(joined, io.ErrUnexpectedEOF))
+	assert.True(Is(joined, Class("first")))
+
+	var structured structuredError
+	assert.True(errors.As(joined, &structured))
+	assert.Equal("third", structured.value)
+}
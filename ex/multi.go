@@ -8,6 +8,7 @@ Use of this source code is governed by a MIT license that can be found in the LI
 package ex
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -35,11 +36,16 @@ func Append(err error, errs ...error) error {
 	return Multi(all)
 }
 
-// Unwrap unwraps multi-errors.
+// Unwrap unwraps multi-errors, including exceptions with a multi-error class (as returned by `Join`).
 func Unwrap(err error) []error {
 	if typed, ok := err.(Multi); ok {
 		return []error(typed)
 	}
+	if typed := As(err); typed != nil {
+		if multi, ok := typed.Class.(Multi); ok {
+			return []error(multi)
+		}
+	}
 	return []error{err}
 }
 
@@ -65,6 +71,26 @@ func (m Multi) Error() string {
 		len(m), strings.Join(points, "\n\t"))
 }
 
+// Is returns if any of the errors match a given target with `errors.Is`.
+func (m Multi) Is(target error) bool {
+	for _, err := range m {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As returns if any of the errors can be assigned to a given target with `errors.As`.
+func (m Multi) As(target interface{}) bool {
+	for _, err := range m {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 // WrappedErrors implements something in errors.
 func (m Multi) WrappedErrors() []error {
 	return m
@@ -26,6 +26,17 @@ func OptMessagef(format string, args ...interface{}) Option {
 	}
 }
 
+// OptMetadata adds a structured key/value annotation to the exception, e.g. `ex.OptMetadata("user_id", 42)`.
+// Annotations are kept when the exception is wrapped, and can be read back with `ErrMetadata`.
+func OptMetadata(key string, value interface{}) Option {
+	return func(ex *Ex) {
+		if ex.Metadata == nil {
+			ex.Metadata = make(map[string]interface{})
+		}
+		ex.Metadata[key] = value
+	}
+}
+
 // OptStackTrace sets the exception stack.
 func OptStackTrace(stack StackTrace) Option {
 	return func(ex *Ex) {
@@ -33,6 +44,23 @@ func OptStackTrace(stack StackTrace) Option {
 	}
 }
 
+// OptStackDepth caps the number of frames captured for the exception stack trace.
+// It overrides the package level `MaxStackDepth` and only applies to newly created exceptions.
+func OptStackDepth(depth int) Option {
+	return func(ex *Ex) {
+		ex.stackDepth = depth
+	}
+}
+
+// OptSkipStack skips capturing a stack trace for the exception.
+// Use this for errors created in hot paths where the cost of capturing the stack matters.
+func OptSkipStack() Option {
+	return func(ex *Ex) {
+		ex.skipStack = true
+		ex.StackTrace = nil
+	}
+}
+
 // OptInner sets an inner or wrapped ex.
 func OptInner(inner error) Option {
 	return func(ex *Ex) {
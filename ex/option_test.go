@@ -60,3 +60,53 @@ func TestOptInnerClass(t *testing.T) {
 	assert.NotNil(ex.Inner)
 	assert.Nil(ErrStackTrace(ex.Inner))
 }
+
+func TestOptStackDepth(t *testing.T) {
+	assert := assert.New(t)
+
+	full := As(New("this is only a test"))
+	assert.NotEmpty(full.StackTrace)
+
+	capped := As(New("this is only a test", OptStackDepth(2)))
+	assert.NotNil(capped.StackTrace)
+	assert.Len(capped.StackTrace.(StackPointers), 2)
+	assert.Contains(capped.StackTrace.Strings()[0], "TestOptStackDepth")
+}
+
+func TestOptStackDepthPackageDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func() { MaxStackDepth = DefaultMaxStackDepth }()
+	MaxStackDepth = 1
+
+	assert.Len(As(New("this is only a test")).StackTrace.(StackPointers), 1)
+	assert.Len(As(New("this is only a test", OptStackDepth(3))).StackTrace.(StackPointers), 3)
+
+	MaxStackDepth = 0
+	assert.Nil(As(New("this is only a test")).StackTrace)
+}
+
+func TestOptSkipStack(t *testing.T) {
+	assert := assert.New(t)
+
+	err := New("this is only a test", OptSkipStack())
+	assert.NotNil(err)
+	assert.Nil(As(err).StackTrace)
+	assert.Nil(ErrStackTrace(err))
+	assert.Equal("this is only a test", err.Error())
+	assert.Equal("this is only a test", fmt.Sprintf("%+v", err))
+
+	withStack := New("this is only a test", OptSkipStack(), OptStackTrace(StackStrings([]string{"first"})))
+	assert.Equal([]string{"first"}, ErrStackTrace(withStack).Strings())
+}
+
+func TestOptMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	err := New("this is only a test", OptMetadata("user_id", 42), OptMetadata("path", "/foo"))
+	assert.Equal(map[string]interface{}{"user_id": 42, "path": "/foo"}, As(err).Metadata)
+
+	// options applied to an existing exception add to its metadata.
+	err = New(err, OptMetadata("retry", true))
+	assert.Equal(map[string]interface{}{"user_id": 42, "path": "/foo", "retry": true}, As(err).Metadata)
+}
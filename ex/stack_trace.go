@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"path"
 	"runtime"
+	"strconv"
 	"strings"
 )
 
@@ -25,11 +26,26 @@ func GetStackTrace() string {
 	return fmt.Sprintf("%+v", Callers(DefaultStartDepth))
 }
 
+// MaxStackDepth is the maximum number of frames captured for a stack trace by `Callers`.
+//
+// It is a package level default and should be set before any exceptions are created;
+// individual exceptions can override it with `OptStackDepth`.
+var MaxStackDepth = DefaultMaxStackDepth
+
 // Callers returns stack pointers.
 func Callers(startDepth int) StackPointers {
-	const depth = 32
-	var pcs [depth]uintptr
-	n := runtime.Callers(startDepth, pcs[:])
+	return CallersWithDepth(startDepth+1, MaxStackDepth)
+}
+
+// CallersWithDepth returns at most a given number of stack pointers.
+//
+// If the max depth is less than or equal to zero, it returns nil.
+func CallersWithDepth(startDepth, maxDepth int) StackPointers {
+	if maxDepth <= 0 {
+		return nil
+	}
+	pcs := make([]uintptr, maxDepth)
+	n := runtime.Callers(startDepth, pcs)
 	var st StackPointers = pcs[0:n]
 	return st
 }
@@ -88,6 +104,79 @@ func (st StackPointers) MarshalJSON() ([]byte, error) {
 	return json.Marshal(st.Strings())
 }
 
+// StackFrame is a machine readable stack frame.
+type StackFrame struct {
+	File string `json:"file,omitempty"`
+	Func string `json:"func,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// StackFrames returns the machine readable frames for a stack trace.
+//
+// Stack pointers are resolved with `runtime.CallersFrames`, and stack strings
+// are parsed from the `%+v` frame format. It returns nil for an empty stack.
+func StackFrames(st StackTrace) []StackFrame {
+	switch typed := st.(type) {
+	case StackPointers:
+		if len(typed) == 0 {
+			return nil
+		}
+		var output []StackFrame
+		runtimeFrames := runtime.CallersFrames(typed)
+		for {
+			frame, more := runtimeFrames.Next()
+			output = append(output, StackFrame{
+				File: frame.File,
+				Func: frame.Function,
+				Line: frame.Line,
+			})
+			if !more {
+				break
+			}
+		}
+		return output
+	case StackStrings:
+		if len(typed) == 0 {
+			return nil
+		}
+		output := make([]StackFrame, 0, len(typed))
+		for _, value := range typed {
+			output = append(output, parseStackFrame(value))
+		}
+		return output
+	case nil:
+		return nil
+	default:
+		return StackFrames(StackStrings(typed.Strings()))
+	}
+}
+
+// StackFramesToStrings returns stack strings in the `%+v` frame format for a given set of frames.
+func StackFramesToStrings(frames []StackFrame) StackStrings {
+	output := make(StackStrings, 0, len(frames))
+	for _, frame := range frames {
+		output = append(output, fmt.Sprintf("%s\n\t%s:%d", frame.Func, frame.File, frame.Line))
+	}
+	return output
+}
+
+// parseStackFrame parses a frame from the `%+v` frame format, i.e. `func\n\tfile:line`.
+func parseStackFrame(value string) (frame StackFrame) {
+	fileLine := value
+	if index := strings.Index(value, "\n\t"); index >= 0 {
+		frame.Func = value[:index]
+		fileLine = value[index+2:]
+	}
+	frame.File = fileLine
+	if index := strings.LastIndex(fileLine, ":"); index >= 0 {
+		if line, err := strconv.Atoi(fileLine[index+1:]); err == nil {
+			frame.File = fileLine[:index]
+			frame.Line = line
+		}
+	}
+	return
+}
+
 // StackStrings represents a stack trace as string literals.
 type StackStrings []string
 
@@ -35,7 +35,7 @@ func main() {
 	app, err := web.New(
 		web.OptConfigFromEnv(),
 		web.OptLog(log),
-		web.OptUse(web.GZip), // NOTE: as of v3.0.0 gzip response compression middleware is not enabled by default, you _must_ enable it explicitly.
+		web.OptUse(web.Compression()), // NOTE: as of v3.0.0 response compression middleware is not enabled by default, you _must_ enable it explicitly.
 		web.OptShutdownGracePeriod(time.Second),
 	)
 	if err != nil {
@@ -20,7 +20,7 @@ func main() {
 	app := web.MustNew(
 		web.OptLog(log),
 		web.OptConfigFromEnv(),
-		web.OptUse(web.GZip),
+		web.OptUse(web.Compression()),
 	)
 	app.GET("/", func(_ *web.Ctx) web.Result { return web.Text.Result("OK!") })
 	if err := graceful.Shutdown(app); err != nil {
@@ -0,0 +1,115 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// DirSizeOption is an option for DirSize.
+type DirSizeOption func(*DirSizeOptions)
+
+// OptFollowSymlinks sets if symlinks should be followed, in which case the
+// size of the files (and directories) they point to are included in the total.
+func OptFollowSymlinks(followSymlinks bool) DirSizeOption {
+	return func(dso *DirSizeOptions) { dso.FollowSymlinks = followSymlinks }
+}
+
+// OptIgnorePermissionErrors sets if files and directories that cannot be read
+// because of permissions should be skipped instead of returning an error.
+func OptIgnorePermissionErrors(ignorePermissionErrors bool) DirSizeOption {
+	return func(dso *DirSizeOptions) { dso.IgnorePermissionErrors = ignorePermissionErrors }
+}
+
+// DirSizeOptions are options for DirSize.
+type DirSizeOptions struct {
+	// FollowSymlinks includes the targets of symlinks in the total, otherwise symlinks are skipped.
+	FollowSymlinks bool
+	// IgnorePermissionErrors skips paths that cannot be read instead of returning an error.
+	IgnorePermissionErrors bool
+}
+
+// DirSize walks a directory tree and returns the total size in bytes of the regular files in it.
+//
+// By default symlinks are skipped, and any error aborts the walk.
+func DirSize(path string, opts ...DirSizeOption) (int64, error) {
+	var options DirSizeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return 0, ex.New(err)
+	}
+	if !stat.IsDir() {
+		return stat.Size(), nil
+	}
+	return dirSize(path, options, make(map[string]bool))
+}
+
+func dirSize(path string, options DirSizeOptions, visited map[string]bool) (int64, error) {
+	if options.FollowSymlinks {
+		// guard against symlink cycles
+		realPath, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return 0, ex.New(err)
+		}
+		if visited[realPath] {
+			return 0, nil
+		}
+		visited[realPath] = true
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if options.IgnorePermissionErrors && os.IsPermission(err) {
+			return 0, nil
+		}
+		return 0, ex.New(err)
+	}
+
+	var total int64
+	for _, entry := range entries {
+		entryPath := filepath.Join(path, entry.Name())
+		var info os.FileInfo
+		if entry.Type()&os.ModeSymlink != 0 {
+			if !options.FollowSymlinks {
+				continue
+			}
+			info, err = os.Stat(entryPath)
+			if os.IsNotExist(err) {
+				// broken symlinks don't contribute to the total
+				continue
+			}
+		} else {
+			info, err = entry.Info()
+		}
+		if err != nil {
+			if options.IgnorePermissionErrors && os.IsPermission(err) {
+				continue
+			}
+			return 0, ex.New(err)
+		}
+
+		switch {
+		case info.IsDir():
+			size, err := dirSize(entryPath, options, visited)
+			if err != nil {
+				return 0, err
+			}
+			total += size
+		case info.Mode().IsRegular():
+			total += info.Size()
+		}
+	}
+	return total, nil
+}
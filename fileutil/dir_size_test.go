@@ -0,0 +1,78 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package fileutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/fileutil"
+)
+
+func writeTestFile(it *assert.Assertions, path string, size int) {
+	it.Nil(os.MkdirAll(filepath.Dir(path), 0755))
+	it.Nil(os.WriteFile(path, []byte(strings.Repeat("a", size)), 0644))
+}
+
+func TestDirSize(t *testing.T) {
+	t.Parallel()
+	it := assert.New(t)
+
+	dir := tempDir(it)
+	writeTestFile(it, filepath.Join(dir, "one"), 10)
+	writeTestFile(it, filepath.Join(dir, "nested", "two"), 20)
+	writeTestFile(it, filepath.Join(dir, "nested", "deeper", "three"), 30)
+
+	other := tempDir(it)
+	writeTestFile(it, filepath.Join(other, "four"), 40)
+	it.Nil(os.Symlink(filepath.Join(other, "four"), filepath.Join(dir, "link-file")))
+	it.Nil(os.Symlink(other, filepath.Join(dir, "link-dir")))
+	it.Nil(os.Symlink(dir, filepath.Join(dir, "nested", "link-cycle")))
+	it.Nil(os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "link-broken")))
+
+	size, err := fileutil.DirSize(dir)
+	it.Nil(err)
+	it.Equal(60, size)
+
+	// the file symlink and the directory symlink are counted, the cycle only once
+	size, err = fileutil.DirSize(dir, fileutil.OptFollowSymlinks(true))
+	it.Nil(err)
+	it.Equal(140, size)
+
+	size, err = fileutil.DirSize(filepath.Join(dir, "one"))
+	it.Nil(err)
+	it.Equal(10, size)
+
+	_, err = fileutil.DirSize(filepath.Join(dir, "missing"))
+	it.NotNil(err)
+}
+
+func TestDirSizeIgnorePermissionErrors(t *testing.T) {
+	t.Parallel()
+	it := assert.New(t)
+
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+
+	dir := tempDir(it)
+	writeTestFile(it, filepath.Join(dir, "one"), 10)
+	writeTestFile(it, filepath.Join(dir, "locked", "two"), 20)
+	it.Nil(os.Chmod(filepath.Join(dir, "locked"), 0000))
+	defer func() { _ = os.Chmod(filepath.Join(dir, "locked"), 0755) }()
+
+	_, err := fileutil.DirSize(dir)
+	it.NotNil(err)
+
+	size, err := fileutil.DirSize(dir, fileutil.OptIgnorePermissionErrors(true))
+	it.Nil(err)
+	it.Equal(10, size)
+}
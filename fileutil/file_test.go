@@ -9,9 +9,12 @@ package fileutil
 
 import (
 	"crypto/rand"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
 )
 
 func TestFileReadByLines(t *testing.T) {
@@ -36,6 +39,58 @@ func TestFileReadByLines(t *testing.T) {
 	assert.True(lineCorrect, "The first line should have matched the input")
 }
 
+func TestFileReadByLinesLongLines(t *testing.T) {
+	assert := assert.New(t)
+
+	long := strings.Repeat("a", 64)
+	f, err := NewTemp([]byte("short\n" + long + "\r\nshort again\n" + long))
+	assert.Nil(err)
+	defer f.Close()
+
+	var lines []string
+	err = ReadLines(f.Name(), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	}, OptReadLinesMaxLineSize(16))
+	assert.True(ex.Is(err, ErrLineTooLong))
+	assert.Equal([]string{"short"}, lines)
+
+	lines = nil
+	err = ReadLines(f.Name(), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	}, OptReadLinesMaxLineSize(16), OptReadLinesSkipLongLines(true))
+	assert.Nil(err)
+	assert.Equal([]string{"short", "short again"}, lines)
+
+	lines = nil
+	err = ReadLines(f.Name(), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	}, OptReadLinesMaxLineSize(64))
+	assert.Nil(err)
+	assert.Equal([]string{"short", long, "short again", long}, lines)
+}
+
+func TestFileReadByLinesHandlerError(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := NewTemp([]byte("one\ntwo\nthree\n"))
+	assert.Nil(err)
+	defer f.Close()
+
+	var lines []string
+	err = ReadLines(f.Name(), func(line string) error {
+		lines = append(lines, line)
+		if line == "two" {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+	assert.Equal("stop", ex.ErrClass(err).Error())
+	assert.Equal([]string{"one", "two"}, lines)
+}
+
 func TestFileReadByChunks(t *testing.T) {
 	assert := assert.New(t)
 
@@ -7,7 +7,10 @@ Use of this source code is governed by a MIT license that can be found in the LI
 
 package fileutil
 
-import "strconv"
+import (
+	"math"
+	"strconv"
+)
 
 const (
 	// Kilobyte represents the bytes in a kilobyte.
@@ -34,3 +37,55 @@ func FormatFileSize(sizeBytes int64) string {
 	}
 	return strconv.FormatInt(sizeBytes, 10)
 }
+
+// FormatFileSizePrecision returns a string representation of a file size in bytes
+// with up to `digits` decimal places, e.g. 1536 bytes with 1 digit is "1.5kb".
+//
+// Values are truncated (not rounded) to the given number of digits, and a digits
+// value of zero (or less) is the same as calling `FormatFileSize`.
+func FormatFileSizePrecision(sizeBytes int64, digits int) string {
+	if digits <= 0 {
+		return FormatFileSize(sizeBytes)
+	}
+	return formatFileSize(sizeBytes, digits, fileSizeLabels)
+}
+
+// FormatFileSizeIEC returns a string representation of a file size in bytes
+// with up to `digits` decimal places, using IEC binary unit labels, i.e. "B", "KiB",
+// "MiB", "GiB" and "TiB", e.g. 1536 bytes with 1 digit is "1.5KiB".
+//
+// Values are truncated (not rounded) to the given number of digits.
+func FormatFileSizeIEC(sizeBytes int64, digits int) string {
+	return formatFileSize(sizeBytes, digits, fileSizeLabelsIEC)
+}
+
+// fileSizeLabelSet are the unit labels for bytes, kilobytes, megabytes, gigabytes and terabytes.
+type fileSizeLabelSet [5]string
+
+var (
+	fileSizeLabels    = fileSizeLabelSet{"", "kb", "mb", "gb", "tb"}
+	fileSizeLabelsIEC = fileSizeLabelSet{"B", "KiB", "MiB", "GiB", "TiB"}
+)
+
+func formatFileSize(sizeBytes int64, digits int, labels fileSizeLabelSet) string {
+	switch {
+	case sizeBytes >= Terabyte:
+		return formatFileSizeUnit(sizeBytes, Terabyte, digits) + labels[4]
+	case sizeBytes >= Gigabyte:
+		return formatFileSizeUnit(sizeBytes, Gigabyte, digits) + labels[3]
+	case sizeBytes >= Megabyte:
+		return formatFileSizeUnit(sizeBytes, Megabyte, digits) + labels[2]
+	case sizeBytes >= Kilobyte:
+		return formatFileSizeUnit(sizeBytes, Kilobyte, digits) + labels[1]
+	}
+	return strconv.FormatInt(sizeBytes, 10) + labels[0]
+}
+
+func formatFileSizeUnit(sizeBytes, unit int64, digits int) string {
+	if digits <= 0 {
+		return strconv.FormatInt(sizeBytes/unit, 10)
+	}
+	scale := math.Pow10(digits)
+	value := math.Floor(float64(sizeBytes)/float64(unit)*scale) / scale
+	return strconv.FormatFloat(value, 'f', digits, 64)
+}
@@ -0,0 +1,48 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package fileutil
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestFormatFileSize(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("512", FormatFileSize(512))
+	assert.Equal("1kb", FormatFileSize(1536))
+	assert.Equal("2mb", FormatFileSize(2*Megabyte+Megabyte/2))
+	assert.Equal("3gb", FormatFileSize(3*Gigabyte))
+	assert.Equal("4tb", FormatFileSize(4*Terabyte))
+}
+
+func TestFormatFileSizePrecision(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("1kb", FormatFileSizePrecision(1536, 0))
+	assert.Equal("1.5kb", FormatFileSizePrecision(1536, 1))
+	assert.Equal("1.50kb", FormatFileSizePrecision(1536, 2))
+	assert.Equal("512", FormatFileSizePrecision(512, 2))
+	assert.Equal("1.9kb", FormatFileSizePrecision(2047, 1), "values are truncated")
+	assert.Equal("2.25mb", FormatFileSizePrecision(2*Megabyte+Megabyte/4, 2))
+	assert.Equal("1.0gb", FormatFileSizePrecision(Gigabyte, 1))
+	assert.Equal("1.5tb", FormatFileSizePrecision(Terabyte+Terabyte/2, 1))
+}
+
+func TestFormatFileSizeIEC(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("512B", FormatFileSizeIEC(512, 1))
+	assert.Equal("1KiB", FormatFileSizeIEC(1536, 0))
+	assert.Equal("1.5KiB", FormatFileSizeIEC(1536, 1))
+	assert.Equal("2.25MiB", FormatFileSizeIEC(2*Megabyte+Megabyte/4, 2))
+	assert.Equal("3.0GiB", FormatFileSizeIEC(3*Gigabyte, 1))
+	assert.Equal("1.5TiB", FormatFileSizeIEC(Terabyte+Terabyte/2, 1))
+}
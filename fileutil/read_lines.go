@@ -9,26 +9,111 @@ package fileutil
 
 import (
 	"bufio"
+	"io"
 	"os"
 
 	"github.com/blend/go-sdk/ex"
 )
 
+// ReadLines constants
+const (
+	ErrLineTooLong ex.Class = "line exceeds max line size"
+	// DefaultReadLinesMaxLineSize is the default max line size, which
+	// matches the default max token size of a `bufio.Scanner`.
+	DefaultReadLinesMaxLineSize = bufio.MaxScanTokenSize
+)
+
+// ReadLinesOption is an option for ReadLines.
+type ReadLinesOption func(*ReadLinesOptions)
+
+// OptReadLinesMaxLineSize sets the maximum line size in bytes.
+func OptReadLinesMaxLineSize(maxLineSize int) ReadLinesOption {
+	return func(rlo *ReadLinesOptions) { rlo.MaxLineSize = maxLineSize }
+}
+
+// OptReadLinesSkipLongLines sets if lines longer than the maximum
+// line size should be skipped instead of returning an error.
+func OptReadLinesSkipLongLines(skipLongLines bool) ReadLinesOption {
+	return func(rlo *ReadLinesOptions) { rlo.SkipLongLines = skipLongLines }
+}
+
+// ReadLinesOptions are options for ReadLines.
+type ReadLinesOptions struct {
+	// MaxLineSize is the maximum line size in bytes, it defaults to `DefaultReadLinesMaxLineSize`.
+	MaxLineSize int
+	// SkipLongLines skips lines longer than the maximum line size instead of returning an error.
+	SkipLongLines bool
+}
+
+// MaxLineSizeOrDefault returns the max line size or a default.
+func (rlo ReadLinesOptions) MaxLineSizeOrDefault() int {
+	if rlo.MaxLineSize > 0 {
+		return rlo.MaxLineSize
+	}
+	return DefaultReadLinesMaxLineSize
+}
+
 // ReadLines reads a file and calls the handler for each line.
-func ReadLines(filePath string, handler func(string) error) error {
+//
+// Lines longer than the max line size (see `OptReadLinesMaxLineSize`) return
+// an `ErrLineTooLong` error, unless they're skipped with `OptReadLinesSkipLongLines`.
+// The first error returned by the handler stops reading the file and is returned.
+func ReadLines(filePath string, handler func(string) error, opts ...ReadLinesOption) error {
+	var options ReadLinesOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	f, err := os.Open(filePath)
 	if err != nil {
 		return ex.New(err)
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		err = handler(line)
+	maxLineSize := options.MaxLineSizeOrDefault()
+	reader := bufio.NewReaderSize(f, maxLineSize)
+
+	var line []byte
+	var pending, tooLong bool
+	handleLine := func() error {
+		defer func() {
+			line = line[:0]
+			pending, tooLong = false, false
+		}()
+		if tooLong {
+			if options.SkipLongLines {
+				return nil
+			}
+			return ex.New(ErrLineTooLong, ex.OptMessagef("max line size: %d", maxLineSize))
+		}
+		if err := handler(string(line)); err != nil {
+			return ex.New(err)
+		}
+		return nil
+	}
+
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err == io.EOF {
+			// the last line may have been a prefix without a trailing newline
+			if pending {
+				return handleLine()
+			}
+			return nil
+		}
 		if err != nil {
 			return ex.New(err)
 		}
+		if !tooLong {
+			line = append(line, chunk...)
+			tooLong = len(line) > maxLineSize
+		}
+		if isPrefix {
+			pending = true
+			continue
+		}
+		if err = handleLine(); err != nil {
+			return err
+		}
 	}
-	return nil
 }
@@ -0,0 +1,158 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package fileutil
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// DefaultWatchDebounce is the default time to wait for changes to settle before calling a `WatchFunc` action.
+const DefaultWatchDebounce = 100 * time.Millisecond
+
+// WatchFuncOption mutates watch func options.
+type WatchFuncOption func(*WatchFuncOptions)
+
+// OptWatchPollInterval sets how often the file is checked for changes.
+func OptWatchPollInterval(d time.Duration) WatchFuncOption {
+	return func(wfo *WatchFuncOptions) { wfo.PollInterval = d }
+}
+
+// OptWatchDebounce sets how long changes have to settle before the action is called.
+func OptWatchDebounce(d time.Duration) WatchFuncOption {
+	return func(wfo *WatchFuncOptions) { wfo.Debounce = d }
+}
+
+// OptWatchErrors sets the channel errors from the action, or from checking the file, are sent to.
+func OptWatchErrors(errors chan error) WatchFuncOption {
+	return func(wfo *WatchFuncOptions) { wfo.Errors = errors }
+}
+
+// WatchFuncOptions are options for `WatchFunc`.
+type WatchFuncOptions struct {
+	PollInterval time.Duration
+	Debounce     time.Duration
+	Errors       chan error
+}
+
+// PollIntervalOrDefault returns the polling interval or a default.
+func (wfo WatchFuncOptions) PollIntervalOrDefault() time.Duration {
+	if wfo.PollInterval > 0 {
+		return wfo.PollInterval
+	}
+	return DefaultWatchPollInterval
+}
+
+// DebounceOrDefault returns the debounce or a default.
+func (wfo WatchFuncOptions) DebounceOrDefault() time.Duration {
+	if wfo.Debounce > 0 {
+		return wfo.Debounce
+	}
+	return DefaultWatchDebounce
+}
+
+// WatchFunc watches a file for changes in a background goroutine and calls the action
+// when it is modified, returning a function that stops the watch.
+//
+// The file is polled by path, so it survives the file being replaced with an atomic rename,
+// and a missing file is treated as a change that has not happened yet rather than an error.
+// Changes within the debounce window of each other are coalesced into one call.
+//
+// Errors returned by the action are sent to the channel set with `OptWatchErrors`, if any,
+// and watching continues; return `ErrWatchStopped` from the action to stop watching.
+// The stop function waits for an in-flight action to finish, so it must not be called from the action.
+func WatchFunc(path string, action func() error, opts ...WatchFuncOption) (stop func()) {
+	var options WatchFuncOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	stopping := make(chan struct{})
+	stopped := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() { close(stopping) })
+		<-stopped
+	}
+
+	// stat the file before returning so changes made right after are seen.
+	last, statErr := os.Stat(path)
+
+	go func() {
+		defer close(stopped)
+		if statErr != nil && !os.IsNotExist(statErr) {
+			handleWatchFuncError(options.Errors, stopping, ex.New(statErr))
+		}
+
+		ticker := time.NewTicker(options.PollIntervalOrDefault())
+		defer ticker.Stop()
+		debounce := time.NewTimer(options.DebounceOrDefault())
+		if !debounce.Stop() {
+			<-debounce.C
+		}
+		defer debounce.Stop()
+
+		for {
+			select {
+			case <-stopping:
+				return
+			case <-ticker.C:
+				current, err := os.Stat(path)
+				if err != nil {
+					if !os.IsNotExist(err) {
+						handleWatchFuncError(options.Errors, stopping, ex.New(err))
+					}
+					last = nil
+					continue
+				}
+				if watchFuncChanged(last, current) {
+					if !debounce.Stop() {
+						select {
+						case <-debounce.C:
+						default:
+						}
+					}
+					debounce.Reset(options.DebounceOrDefault())
+				}
+				last = current
+			case <-debounce.C:
+				if err := action(); err != nil {
+					if ex.Is(err, ErrWatchStopped) {
+						return
+					}
+					handleWatchFuncError(options.Errors, stopping, ex.New(err))
+				}
+			}
+		}
+	}()
+	return stop
+}
+
+// watchFuncChanged returns if a file has been modified or replaced since it was last checked.
+func watchFuncChanged(last, current os.FileInfo) bool {
+	if last == nil {
+		return true
+	}
+	return !current.ModTime().Equal(last.ModTime()) ||
+		current.Size() != last.Size() ||
+		!os.SameFile(last, current)
+}
+
+// handleWatchFuncError sends an error to the errors channel if it's set, giving up if the watch is stopping.
+func handleWatchFuncError(errors chan error, stopping chan struct{}, err error) {
+	if errors == nil {
+		return
+	}
+	select {
+	case errors <- err:
+	case <-stopping:
+	}
+}
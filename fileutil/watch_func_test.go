@@ -0,0 +1,185 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package fileutil_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/fileutil"
+)
+
+func watchFuncTestOptions(opts ...fileutil.WatchFuncOption) []fileutil.WatchFuncOption {
+	return append([]fileutil.WatchFuncOption{
+		fileutil.OptWatchPollInterval(5 * time.Millisecond),
+		fileutil.OptWatchDebounce(50 * time.Millisecond),
+	}, opts...)
+}
+
+func watchFuncTestWrite(its *assert.Assertions, path, contents string) {
+	its.Nil(os.WriteFile(path, []byte(contents), 0644))
+	// make sure the mod time moves on filesystems with coarse timestamps.
+	later := time.Now().Add(time.Duration(len(contents)) * time.Second)
+	its.Nil(os.Chtimes(path, later, later))
+}
+
+func TestWatchFunc(t *testing.T) {
+	its := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	watchFuncTestWrite(its, path, "a")
+
+	calls := make(chan struct{}, 10)
+	stop := fileutil.WatchFunc(path, func() error {
+		calls <- struct{}{}
+		return nil
+	}, watchFuncTestOptions()...)
+	defer stop()
+
+	// rapid edits are coalesced into one call.
+	watchFuncTestWrite(its, path, "ab")
+	watchFuncTestWrite(its, path, "abc")
+	watchFuncTestWrite(its, path, "abcd")
+
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the action")
+	}
+	select {
+	case <-calls:
+		t.Fatal("rapid edits should be coalesced")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatchFuncAtomicReplace(t *testing.T) {
+	its := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	watchFuncTestWrite(its, path, "a")
+
+	calls := make(chan struct{}, 10)
+	stop := fileutil.WatchFunc(path, func() error {
+		calls <- struct{}{}
+		return nil
+	}, watchFuncTestOptions()...)
+	defer stop()
+
+	its.Nil(fileutil.WriteAtomic(path, 0644, bytes.NewBufferString("b")))
+
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the action")
+	}
+
+	// the watch continues on the replaced file.
+	watchFuncTestWrite(its, path, "bc")
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the action")
+	}
+}
+
+func TestWatchFuncCreated(t *testing.T) {
+	its := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+
+	calls := make(chan struct{}, 10)
+	stop := fileutil.WatchFunc(path, func() error {
+		calls <- struct{}{}
+		return nil
+	}, watchFuncTestOptions()...)
+	defer stop()
+
+	watchFuncTestWrite(its, path, "a")
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the action")
+	}
+}
+
+func TestWatchFuncErrors(t *testing.T) {
+	its := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	watchFuncTestWrite(its, path, "a")
+
+	errors := make(chan error)
+	stop := fileutil.WatchFunc(path, func() error {
+		return fmt.Errorf("bad config")
+	}, watchFuncTestOptions(fileutil.OptWatchErrors(errors))...)
+	defer stop()
+
+	watchFuncTestWrite(its, path, "ab")
+	select {
+	case err := <-errors:
+		its.Equal("bad config", ex.ErrClass(err).Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the error")
+	}
+
+	// the watch continues after an action error.
+	watchFuncTestWrite(its, path, "abc")
+	select {
+	case err := <-errors:
+		its.NotNil(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the error")
+	}
+}
+
+func TestWatchFuncStop(t *testing.T) {
+	its := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	watchFuncTestWrite(its, path, "a")
+
+	var calls int32
+	stop := fileutil.WatchFunc(path, func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}, watchFuncTestOptions()...)
+	stop()
+	stop()
+
+	watchFuncTestWrite(its, path, "ab")
+	time.Sleep(100 * time.Millisecond)
+	its.Zero(atomic.LoadInt32(&calls))
+}
+
+func TestWatchFuncErrWatchStopped(t *testing.T) {
+	its := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	watchFuncTestWrite(its, path, "a")
+
+	var calls int32
+	stop := fileutil.WatchFunc(path, func() error {
+		atomic.AddInt32(&calls, 1)
+		return ex.New(fileutil.ErrWatchStopped)
+	}, watchFuncTestOptions()...)
+	defer stop()
+
+	watchFuncTestWrite(its, path, "ab")
+	time.Sleep(150 * time.Millisecond)
+	watchFuncTestWrite(its, path, "abc")
+	time.Sleep(150 * time.Millisecond)
+	its.Equal(1, atomic.LoadInt32(&calls))
+}
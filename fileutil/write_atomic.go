@@ -0,0 +1,57 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package fileutil
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// WriteAtomic writes the contents of a reader to a given path such that readers of
+// the path will either see the previous contents or the new contents, but never a partial write.
+//
+// It writes to a temp file in the same directory as the path, fsyncs it, and renames it over the path.
+// The temp file is removed if any step fails. The path does not need to exist beforehand, and the
+// resulting file will have the given mode.
+func WriteAtomic(path string, mode os.FileMode, r io.Reader) (err error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	temp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return ex.New(err)
+	}
+	tempPath := temp.Name()
+	defer func() {
+		if err != nil {
+			_ = temp.Close()
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	if _, err = io.Copy(temp, r); err != nil {
+		return ex.New(err)
+	}
+	if err = temp.Chmod(mode); err != nil {
+		return ex.New(err)
+	}
+	if err = temp.Sync(); err != nil {
+		return ex.New(err)
+	}
+	if err = temp.Close(); err != nil {
+		return ex.New(err)
+	}
+	if err = os.Rename(tempPath, path); err != nil {
+		return ex.New(err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package fileutil_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/fileutil"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(_ []byte) (int, error) {
+	return 0, fmt.Errorf("read failed")
+}
+
+func TestWriteAtomic(t *testing.T) {
+	t.Parallel()
+	it := assert.New(t)
+
+	dir := tempDir(it)
+	path := filepath.Join(dir, "config.yml")
+
+	// the destination does not exist yet
+	it.Nil(fileutil.WriteAtomic(path, 0640, bytes.NewBufferString("first")))
+	contents, err := os.ReadFile(path)
+	it.Nil(err)
+	it.Equal("first", string(contents))
+	stat, err := os.Stat(path)
+	it.Nil(err)
+	it.Equal(os.FileMode(0640), stat.Mode().Perm())
+
+	// the destination is replaced
+	it.Nil(fileutil.WriteAtomic(path, 0600, bytes.NewBufferString("second")))
+	contents, err = os.ReadFile(path)
+	it.Nil(err)
+	it.Equal("second", string(contents))
+	stat, err = os.Stat(path)
+	it.Nil(err)
+	it.Equal(os.FileMode(0600), stat.Mode().Perm())
+
+	// failures leave the destination, and no temp files, behind
+	it.NotNil(fileutil.WriteAtomic(path, 0600, failingReader{}))
+	contents, err = os.ReadFile(path)
+	it.Nil(err)
+	it.Equal("second", string(contents))
+
+	entries, err := os.ReadDir(dir)
+	it.Nil(err)
+	it.Len(entries, 1)
+}
+
+func TestWriteAtomicMissingDir(t *testing.T) {
+	t.Parallel()
+	it := assert.New(t)
+
+	dir := tempDir(it)
+	it.NotNil(fileutil.WriteAtomic(filepath.Join(dir, "missing", "config.yml"), 0600, bytes.NewBufferString("contents")))
+}
@@ -0,0 +1,20 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package graceful
+
+import "github.com/blend/go-sdk/ex"
+
+// Errors
+const (
+	ErrShutdownTimeout ex.Class = "graceful; timed out waiting for hosted processes to stop"
+)
+
+// IsShutdownTimeout returns if an error is a shutdown timeout error.
+func IsShutdownTimeout(err error) bool {
+	return ex.Is(err, ErrShutdownTimeout)
+}
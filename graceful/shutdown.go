@@ -7,7 +7,9 @@ Use of this source code is governed by a MIT license that can be found in the LI
 
 package graceful
 
-// Shutdown racefully stops a set hosted processes based on SIGINT or SIGTERM received from the os.
+import "os"
+
+// Shutdown gracefully stops a set hosted processes based on SIGINT or SIGTERM received from the os.
 // It will return any errors returned by Start() that are not caused by shutting down the server.
 // A "Graceful" processes *must* block on start.
 func Shutdown(hosted ...Graceful) error {
@@ -15,3 +17,12 @@ func Shutdown(hosted ...Graceful) error {
 		OptDefaultShutdownSignal(),
 	)
 }
+
+// ShutdownBySignals gracefully stops a hosted process when any of a given set of os signals is received.
+//
+// If no signals are provided, it defaults to `DefaultShutdownSignals` (SIGINT and SIGTERM).
+func ShutdownBySignals(hosted Graceful, signals ...os.Signal) error {
+	return ShutdownBySignal([]Graceful{hosted},
+		OptShutdownSignals(signals...),
+	)
+}
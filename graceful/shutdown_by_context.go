@@ -10,8 +10,6 @@ package graceful
 import (
 	"context"
 	"sync"
-
-	"github.com/blend/go-sdk/ex"
 )
 
 // ShutdownByContext gracefully stops a set hosted processes based on context cancellation.
@@ -58,12 +56,5 @@ func ShutdownByContext(ctx context.Context, hosted ...Graceful) error {
 		close(shouldShutdown) // quit the signal listener
 		waitShutdownComplete.Wait()
 	}
-	if errorCount := len(errors); errorCount > 0 {
-		var err error
-		for x := 0; x < errorCount; x++ {
-			err = ex.Append(err, <-errors)
-		}
-		return err
-	}
-	return nil
+	return drainErrors(errors)
 }
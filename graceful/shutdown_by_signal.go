@@ -10,13 +10,16 @@ package graceful
 import (
 	"os/signal"
 	"sync"
+	"time"
 
 	"github.com/blend/go-sdk/ex"
 )
 
 // ShutdownBySignal gracefully stops a set hosted processes based on a set of variadic options.
 // A "Graceful" processes *must* block on start.
-// Fatal errors will be returned, that is, errors that are returned by either .Start() or .Stop().
+// The hosted processes are started together, and on shutdown are stopped concurrently.
+// Fatal errors will be returned, that is, errors that are returned by either .Start() or .Stop(),
+// combined into a single `ex.Multi` error if there are more than one.
 // Panics are not caught by graceful, and it is assumed that your .Start() or .Stop methods will catch relevant panics.
 func ShutdownBySignal(hosted []Graceful, opts ...ShutdownOption) error {
 	var options ShutdownOptions
@@ -61,17 +64,57 @@ func ShutdownBySignal(hosted []Graceful, opts ...ShutdownOption) error {
 	case <-options.ShutdownSignal: // if we've issued a shutdown, wait for the server to exit
 		signal.Stop(options.ShutdownSignal) // unhook the process signal redirects, the next ^c will crash the process etc.
 		close(shouldShutdown)
-		waitShutdownComplete.Wait()
-		waitServerExited.Wait()
+		if !waitTimeout(options.Timeout, &waitShutdownComplete, &waitServerExited) {
+			return ex.New(ErrShutdownTimeout, ex.OptMessagef("timeout: %v", options.Timeout))
+		}
 
 	case <-serverExited: // if any of the servers exited on their own
 		close(shouldShutdown) // quit the signal listener
-		waitShutdownComplete.Wait()
+		if !waitTimeout(options.Timeout, &waitShutdownComplete) {
+			return ex.New(ErrShutdownTimeout, ex.OptMessagef("timeout: %v", options.Timeout))
+		}
 	}
-	if len(errors) > 0 {
-		return <-errors
+	return drainErrors(errors)
+}
+
+// drainErrors combines the errors currently buffered in a given channel into a single error.
+func drainErrors(errors chan error) error {
+	errorCount := len(errors)
+	if errorCount == 0 {
+		return nil
+	}
+	all := make([]error, errorCount)
+	for x := 0; x < errorCount; x++ {
+		all[x] = <-errors
+	}
+	return ex.Append(nil, all...)
+}
+
+// waitTimeout waits for a given set of wait groups to complete, returning
+// false if they didn't complete within a given timeout.
+//
+// A timeout <= 0 waits indefinitely.
+func waitTimeout(timeout time.Duration, waitGroups ...*sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, wg := range waitGroups {
+			wg.Wait()
+		}
+	}()
+	if timeout <= 0 {
+		<-done
+		return true
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
 	}
-	return nil
 }
 
 func safely(action func()) (err error) {
@@ -10,9 +10,11 @@ package graceful
 import (
 	"fmt"
 	"os"
+	"sort"
 	"testing"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
 )
 
 func newHosted() *hosted {
@@ -108,3 +110,51 @@ func TestShutdownBySignalMany(t *testing.T) {
 		assert.Equal(0, h.(*hosted).state)
 	}
 }
+
+// failsToStop is a hosted process that returns an error when stopped.
+type failsToStop struct {
+	*hosted
+	err error
+}
+
+func (fs failsToStop) Stop() error {
+	_ = fs.hosted.Stop()
+	return fs.err
+}
+
+func TestShutdownBySignalManyErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	workers := []Graceful{
+		failsToStop{newHosted(), fmt.Errorf("web")},
+		newHosted(),
+		failsToStop{newHosted(), fmt.Errorf("worker")},
+		failsToStop{newHosted(), fmt.Errorf("metrics")},
+	}
+
+	terminateSignal := make(chan os.Signal)
+	var err error
+	done := make(chan struct{})
+	go func() {
+		err = ShutdownBySignal(workers, OptShutdownSignal(terminateSignal))
+		close(done)
+	}()
+
+	<-workers[0].(failsToStop).started
+	<-workers[1].(*hosted).started
+	<-workers[2].(failsToStop).started
+	<-workers[3].(failsToStop).started
+
+	close(terminateSignal)
+	<-done
+	assert.NotNil(err)
+
+	errs := ex.Unwrap(err)
+	assert.Len(errs, 3)
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, ex.ErrClass(e).Error())
+	}
+	sort.Strings(messages)
+	assert.Equal([]string{"metrics", "web", "worker"}, messages)
+}
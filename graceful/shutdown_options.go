@@ -9,6 +9,7 @@ package graceful
 
 import (
 	"os"
+	"time"
 )
 
 // OptDefaultShutdownSignal returns an option that sets the shutdown signal to the defaults.
@@ -16,15 +17,31 @@ func OptDefaultShutdownSignal() ShutdownOption {
 	return func(so *ShutdownOptions) { so.ShutdownSignal = Notify(DefaultShutdownSignals...) }
 }
 
+// OptShutdownSignals returns an option that sets the shutdown signal to a given set of os signals.
+//
+// If no signals are provided, the `DefaultShutdownSignals` are used.
+func OptShutdownSignals(signals ...os.Signal) ShutdownOption {
+	if len(signals) == 0 {
+		return OptDefaultShutdownSignal()
+	}
+	return func(so *ShutdownOptions) { so.ShutdownSignal = Notify(signals...) }
+}
+
 // OptShutdownSignal sets the shutdown signal.
 func OptShutdownSignal(signal chan os.Signal) ShutdownOption {
 	return func(so *ShutdownOptions) { so.ShutdownSignal = signal }
 }
 
+// OptShutdownTimeout sets the maximum time to wait for hosted processes to stop after a shutdown signal.
+func OptShutdownTimeout(timeout time.Duration) ShutdownOption {
+	return func(so *ShutdownOptions) { so.Timeout = timeout }
+}
+
 // ShutdownOption is a mutator for shutdown options.
 type ShutdownOption func(*ShutdownOptions)
 
 // ShutdownOptions are the options for graceful shutdown.
 type ShutdownOptions struct {
 	ShutdownSignal chan os.Signal
+	Timeout        time.Duration
 }
@@ -0,0 +1,62 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package graceful
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestShutdownBySignals(t *testing.T) {
+	assert := assert.New(t)
+
+	hosted := &stuck{started: make(chan struct{}), release: make(chan struct{})}
+	stopped := &stopRelease{stuck: hosted}
+
+	var err error
+	done := make(chan struct{})
+	go func() {
+		err = ShutdownBySignals(stopped, syscall.SIGHUP)
+		close(done)
+	}()
+	<-hosted.started
+
+	process, findErr := os.FindProcess(os.Getpid())
+	assert.Nil(findErr)
+	assert.Nil(process.Signal(syscall.SIGHUP))
+	<-done
+	assert.Nil(err)
+}
+
+func TestOptShutdownSignals(t *testing.T) {
+	assert := assert.New(t)
+
+	var options ShutdownOptions
+	OptShutdownSignals()(&options)
+	defer signal.Stop(options.ShutdownSignal)
+	assert.NotNil(options.ShutdownSignal)
+
+	options = ShutdownOptions{}
+	OptShutdownSignals(syscall.SIGHUP)(&options)
+	defer signal.Stop(options.ShutdownSignal)
+	assert.NotNil(options.ShutdownSignal)
+}
+
+// stopRelease releases a stuck process when it's stopped.
+type stopRelease struct {
+	*stuck
+}
+
+func (sr stopRelease) Stop() error {
+	close(sr.release)
+	return nil
+}
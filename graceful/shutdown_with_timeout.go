@@ -0,0 +1,22 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package graceful
+
+import "time"
+
+// ShutdownWithTimeout gracefully stops a set of hosted processes based on SIGINT or SIGTERM received from the os,
+// waiting up to a given timeout for them to stop.
+//
+// If the hosted processes haven't stopped by the time the timeout elapses, it returns an
+// `ErrShutdownTimeout` error without waiting further, so that the caller can exit the process.
+func ShutdownWithTimeout(timeout time.Duration, hosted ...Graceful) error {
+	return ShutdownBySignal(hosted,
+		OptDefaultShutdownSignal(),
+		OptShutdownTimeout(timeout),
+	)
+}
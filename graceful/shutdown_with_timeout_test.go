@@ -0,0 +1,72 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package graceful
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+// stuck is a hosted process that doesn't stop when asked to.
+type stuck struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *stuck) Start() error {
+	close(s.started)
+	<-s.release
+	return nil
+}
+
+func (s *stuck) Stop() error {
+	return nil
+}
+
+func TestShutdownBySignalTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	hosted := &stuck{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(hosted.release)
+
+	terminateSignal := make(chan os.Signal)
+	var err error
+	done := make(chan struct{})
+	go func() {
+		err = ShutdownBySignal([]Graceful{hosted}, OptShutdownSignal(terminateSignal), OptShutdownTimeout(10*time.Millisecond))
+		close(done)
+	}()
+	<-hosted.started
+
+	close(terminateSignal)
+	<-done
+	assert.NotNil(err)
+	assert.True(IsShutdownTimeout(err))
+}
+
+func TestShutdownBySignalTimeoutClean(t *testing.T) {
+	assert := assert.New(t)
+
+	hosted := newHosted()
+
+	terminateSignal := make(chan os.Signal)
+	var err error
+	done := make(chan struct{})
+	go func() {
+		err = ShutdownBySignal([]Graceful{hosted}, OptShutdownSignal(terminateSignal), OptShutdownTimeout(time.Second))
+		close(done)
+	}()
+	<-hosted.NotifyStarted()
+
+	close(terminateSignal)
+	<-done
+	assert.Nil(err)
+}
@@ -9,6 +9,7 @@ package grpcutil
 
 import (
 	"context"
+	"math"
 	"math/rand"
 	"time"
 )
@@ -63,6 +64,22 @@ func BackoffExponentialWithJitter(scalar time.Duration, jitterFraction float64)
 	}
 }
 
+// BackoffExponentialWithJitterCap produces exponentially increasing intervals for each attempt, with jitter.
+//
+// The wait is `base * factor^attempt`, capped at `max` (a max of zero disables the cap), which
+// then has jitter (a fractional adjustment) applied. For example base=100ms, factor=2, max=1s and
+// jitter=0.10 generates a wait between 180ms and 220ms for the first attempt, and a
+// wait between 900ms and 1100ms for the 4th attempt onwards.
+func BackoffExponentialWithJitterCap(base time.Duration, factor, jitter float64, max time.Duration) BackoffFunc {
+	return func(attempt uint) time.Duration {
+		wait := float64(base) * math.Pow(factor, float64(attempt))
+		if max > 0 && wait > float64(max) {
+			wait = float64(max)
+		}
+		return JitterUp(time.Duration(wait), jitter)
+	}
+}
+
 // JitterUp adds random jitter to the duration.
 //
 // This adds or subtracts time from the duration within a given jitter fraction.
@@ -8,6 +8,7 @@ Use of this source code is governed by a MIT license that can be found in the LI
 package grpcutil
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -52,3 +53,59 @@ func TestJitterUp(t *testing.T) {
 	assert.True(highCount != 0, fmt.Sprintf("at least one sample should reach to > %s", high))
 	assert.True(lowCount != 0, fmt.Sprintf("at least one sample should to < %s", low))
 }
+
+func TestBackoffExponentialWithJitter(t *testing.T) {
+	assert := assert.New(t)
+
+	backoff := BackoffExponentialWithJitter(100*time.Millisecond, 0)
+	assert.Zero(backoff(0))
+	assert.Equal(100*time.Millisecond, backoff(1))
+	assert.Equal(1600*time.Millisecond, backoff(5))
+}
+
+func TestBackoffExponentialWithJitterCap(t *testing.T) {
+	assert := assert.New(t)
+
+	base := 100 * time.Millisecond
+	max := 2 * time.Second
+	jitter := 0.10
+	backoff := BackoffExponentialWithJitterCap(base, 2, jitter, max)
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		1600 * time.Millisecond,
+		2 * time.Second,
+		2 * time.Second,
+	}
+	for attempt, d := range expected {
+		low := scaleDuration(d, 1-jitter)
+		high := scaleDuration(d, 1+jitter)
+		for i := 0; i < 100; i++ {
+			out := backoff(uint(attempt))
+			assert.True(out >= low, fmt.Sprintf("attempt %d: value %s must be >= %s", attempt, out, low))
+			assert.True(out <= high, fmt.Sprintf("attempt %d: value %s must be <= %s", attempt, out, high))
+		}
+	}
+}
+
+func TestBackoffExponentialWithJitterCapUncapped(t *testing.T) {
+	assert := assert.New(t)
+
+	backoff := BackoffExponentialWithJitterCap(10*time.Millisecond, 3, 0, 0)
+	assert.Equal(10*time.Millisecond, backoff(0))
+	assert.Equal(30*time.Millisecond, backoff(1))
+	assert.Equal(270*time.Millisecond, backoff(3))
+}
+
+func TestBackoffExponentialWithJitterCapRetryOption(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := reuseOrNewWithCallOptions(defaultRetryOptions, []CallOption{
+		WithClientRetryBackoffFunc(BackoffExponentialWithJitterCap(time.Millisecond, 2, 0, 5*time.Millisecond)),
+	})
+	assert.Equal(2*time.Millisecond, opts.backoffFunc(context.Background(), 1))
+	assert.Equal(5*time.Millisecond, opts.backoffFunc(context.Background(), 10))
+}
@@ -42,7 +42,11 @@ var (
 
 // Metadata Keys
 const (
-	MetadataKeyAttempt = "x-retry-attempty"
+	MetadataKeyAttempt = "x-retry-attempt"
+	// MetadataKeyAttemptLegacy is the previous, misspelled, attempt metadata key.
+	//
+	// Deprecated: it is only written if `WithLegacyAttemptHeader` is set, use `MetadataKeyAttempt`.
+	MetadataKeyAttemptLegacy = "x-retry-attempty"
 )
 
 // WithRetriesDisabled disables the retry behavior on this call, or this interceptor.
@@ -91,6 +95,54 @@ func WithClientRetryCodes(retryCodes ...codes.Code) CallOption {
 	}}
 }
 
+// WithLegacyAttemptHeader also writes the attempt number to the previous,
+// misspelled, `MetadataKeyAttemptLegacy` metadata key on retries.
+//
+// Deprecated: this will be removed in a future release, match on `MetadataKeyAttempt` instead.
+func WithLegacyAttemptHeader() CallOption {
+	return CallOption{applyFunc: func(o *retryOptions) {
+		o.includeLegacyHeader = true
+	}}
+}
+
+// WithClientStreamRetryBuffer enables retries for client streaming and bidirectional streaming calls
+// by buffering up to `maxMessages` messages sent on the stream, which are replayed on the new stream
+// when a call is retried.
+//
+// Sending more than `maxMessages` messages fails the call with `ResourceExhausted`. As sent messages
+// are replayed as is, they should not be modified after they are sent, and the calls should be
+// idempotent. A value of 0 disables retries for client streams (the default).
+func WithClientStreamRetryBuffer(maxMessages int) CallOption {
+	return CallOption{applyFunc: func(o *retryOptions) {
+		o.streamRetryBuffer = maxMessages
+	}}
+}
+
+// WithClientRetryOnlyListedCodes only retries errors whose codes are in the retry codes
+// (see `WithClientRetryCodes`), or that match the retry predicate (see `WithClientRetryOn`);
+// all other errors fail fast.
+//
+// By default, errors with codes that are not in the retry codes are also retried.
+func WithClientRetryOnlyListedCodes() CallOption {
+	return CallOption{applyFunc: func(o *retryOptions) {
+		o.abortOnFailure = true
+	}}
+}
+
+// WithClientRetryOn sets a predicate that is consulted, in addition to the retry codes,
+// to determine if an error should be retried.
+//
+// An error is retried if its code is in the retry codes, or the predicate returns true for it.
+// Errors matched by neither are still retried unless `WithClientRetryOnlyListedCodes` is set,
+// i.e. the predicate can add errors to the retried set but cannot prevent an error from being retried.
+//
+// Context errors (Canceled and DeadlineExceeded) are never passed to the predicate.
+func WithClientRetryOn(predicate func(err error) bool) CallOption {
+	return CallOption{applyFunc: func(o *retryOptions) {
+		o.retryOn = predicate
+	}}
+}
+
 // WithClientRetryPerRetryTimeout sets the RPC timeout per call (including initial call) on this call, or this interceptor.
 //
 // The context.Deadline of the call takes precedence and sets the maximum time the whole invocation
@@ -109,6 +161,17 @@ func WithClientRetryPerRetryTimeout(timeout time.Duration) CallOption {
 	}}
 }
 
+// WithOnRetry sets a callback that is called before each retry, i.e. before the
+// backoff wait, with the retry attempt number (starting at 1) and the error
+// that triggered the retry.
+//
+// The attempt number of the last call to the callback is the number of retries a call consumed.
+func WithOnRetry(onRetry func(attempt uint, err error)) CallOption {
+	return CallOption{applyFunc: func(o *retryOptions) {
+		o.onRetry = onRetry
+	}}
+}
+
 type retryOptions struct {
 	max            uint
 	perCallTimeout time.Duration
@@ -116,6 +179,12 @@ type retryOptions struct {
 	codes          []codes.Code
 	backoffFunc    BackoffFuncContext
 	abortOnFailure bool
+	budget         *retryBudget
+	onRetry        func(uint, error)
+	retryOn        func(error) bool
+
+	streamRetryBuffer   int
+	includeLegacyHeader bool
 }
 
 // CallOption is a grpc.CallOption that is local to grpc_retry.
@@ -159,6 +228,7 @@ func RetryUnaryClientInterceptor(optFuncs ...CallOption) grpc.UnaryClientInterce
 		if callOpts.max == 0 {
 			return invoker(parentCtx, method, req, reply, cc, grpcOpts...)
 		}
+		retryBudgetDeposit(callOpts)
 		var lastErr error
 		for attempt := uint(0); attempt < callOpts.max; attempt++ {
 			callCtx, cancel := perCallContext(parentCtx, callOpts, attempt)
@@ -169,6 +239,10 @@ func RetryUnaryClientInterceptor(optFuncs ...CallOption) grpc.UnaryClientInterce
 			if lastErr == nil {
 				return nil
 			}
+			if attempt+1 == callOpts.max {
+				// this was the last attempt, don't spend the budget or wait on a retry.
+				break
+			}
 			if isContextError(lastErr) {
 				if parentCtx.Err() != nil {
 					// its the parent context deadline or cancellation.
@@ -176,12 +250,20 @@ func RetryUnaryClientInterceptor(optFuncs ...CallOption) grpc.UnaryClientInterce
 				} else if callOpts.perCallTimeout != 0 {
 					// We have set a perCallTimeout in the retry middleware, which would result in a context error if
 					// the deadline was exceeded, in which case try again.
+					if !retryBudgetAllows(callOpts) {
+						return lastErr
+					}
+					notifyRetry(callOpts, attempt+1, lastErr)
 					continue
 				}
 			}
 			if !isRetriable(lastErr, callOpts) {
 				return lastErr
 			}
+			if !retryBudgetAllows(callOpts) {
+				return lastErr
+			}
+			notifyRetry(callOpts, attempt+1, lastErr)
 			if err := waitRetryBackoff(parentCtx, attempt, callOpts); err != nil {
 				return err
 			}
@@ -195,9 +277,10 @@ func RetryUnaryClientInterceptor(optFuncs ...CallOption) grpc.UnaryClientInterce
 // The default configuration of the interceptor is to not retry *at all*. This behavior can be
 // changed through options (e.g. WithMax) on creation of the interceptor or on call (through grpc.CallOptions).
 //
-// Retry logic is available *only for ServerStreams*, i.e. 1:n streams, as the internal logic needs
+// Retry logic is available by default *only for ServerStreams*, i.e. 1:n streams, as the internal logic needs
 // to buffer the messages sent by the client. If retry is enabled on any other streams (ClientStreams,
-// BidiStreams), the retry interceptor will fail the call.
+// BidiStreams), the retry interceptor will fail the call, unless a buffer for sent messages is configured
+// with `WithClientStreamRetryBuffer`.
 func RetryStreamClientInterceptor(optFuncs ...CallOption) grpc.StreamClientInterceptor {
 	intOpts := reuseOrNewWithCallOptions(defaultRetryOptions, optFuncs)
 	return func(parentCtx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
@@ -207,12 +290,19 @@ func RetryStreamClientInterceptor(optFuncs ...CallOption) grpc.StreamClientInter
 		if callOpts.max == 0 {
 			return streamer(parentCtx, desc, cc, method, grpcOpts...)
 		}
-		if desc.ClientStreams {
-			return nil, status.Errorf(codes.Unimplemented, "grpc_retry: cannot retry on ClientStreams, set grpc_retry.Disable()")
+		if desc.ClientStreams && callOpts.streamRetryBuffer <= 0 {
+			return nil, status.Errorf(codes.Unimplemented, "grpc_retry: cannot retry on ClientStreams, set grpc_retry.Disable() or grpc_retry.WithClientStreamRetryBuffer()")
 		}
 
+		retryBudgetDeposit(callOpts)
 		var lastErr error
 		for attempt := uint(0); attempt < callOpts.max; attempt++ {
+			if attempt > 0 {
+				if !retryBudgetAllows(callOpts) {
+					return nil, lastErr
+				}
+				notifyRetry(callOpts, attempt, lastErr)
+			}
 			if err := waitRetryBackoff(parentCtx, attempt, callOpts); err != nil {
 				return nil, err
 			}
@@ -225,9 +315,10 @@ func RetryStreamClientInterceptor(optFuncs ...CallOption) grpc.StreamClientInter
 			}()
 			if lastErr == nil {
 				retryingStreamer := &serverStreamingRetryingStream{
-					ClientStream: newStreamer,
-					callOpts:     callOpts,
-					parentCtx:    parentCtx,
+					ClientStream:  newStreamer,
+					clientStreams: desc.ClientStreams,
+					callOpts:      callOpts,
+					parentCtx:     parentCtx,
 					streamerCall: func(ctx context.Context) (grpc.ClientStream, error) {
 						return streamer(ctx, desc, cc, method, grpcOpts...)
 					},
@@ -258,13 +349,17 @@ func RetryStreamClientInterceptor(optFuncs ...CallOption) grpc.StreamClientInter
 // a new ClientStream according to the retry policy.
 type serverStreamingRetryingStream struct {
 	grpc.ClientStream
-	bufferedSends []interface{} // single message that the client can sen
+	clientStreams bool          // indicates the client can send multiple messages, which are buffered up to a limit
+	bufferedSends []interface{} // messages that the client has sent
 	receivedGood  bool          // indicates whether any prior receives were successful
 	wasClosedSend bool          // indicates that CloseSend was closed
 	parentCtx     context.Context
 	callOpts      *retryOptions
 	streamerCall  func(ctx context.Context) (grpc.ClientStream, error)
 	mu            sync.RWMutex
+	// sendMu serializes sends with reestablishing the stream, so that a message is
+	// either replayed on the new stream, or sent on it after it's reestablished.
+	sendMu sync.Mutex
 }
 
 func (s *serverStreamingRetryingStream) setStream(clientStream grpc.ClientStream) {
@@ -280,13 +375,27 @@ func (s *serverStreamingRetryingStream) getStream() grpc.ClientStream {
 }
 
 func (s *serverStreamingRetryingStream) SendMsg(m interface{}) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
 	s.mu.Lock()
-	s.bufferedSends = append(s.bufferedSends, m)
+	// once a message has been received the stream can no longer be retried,
+	// so messages no longer need to be buffered (or limited by the buffer size).
+	if !s.receivedGood {
+		if s.clientStreams && len(s.bufferedSends) >= s.callOpts.streamRetryBuffer {
+			s.mu.Unlock()
+			return status.Errorf(codes.ResourceExhausted, "grpc_retry: client stream retry buffer exceeded; limit: %d", s.callOpts.streamRetryBuffer)
+		}
+		s.bufferedSends = append(s.bufferedSends, m)
+	}
 	s.mu.Unlock()
 	return s.getStream().SendMsg(m)
 }
 
 func (s *serverStreamingRetryingStream) CloseSend() error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
 	s.mu.Lock()
 	s.wasClosedSend = true
 	s.mu.Unlock()
@@ -308,22 +417,24 @@ func (s *serverStreamingRetryingStream) RecvMsg(m interface{}) error {
 	}
 	// We start off from attempt 1, because zeroth was already made on normal SendMsg().
 	for attempt := uint(1); attempt < s.callOpts.max; attempt++ {
+		if !retryBudgetAllows(s.callOpts) {
+			return lastErr
+		}
+		notifyRetry(s.callOpts, attempt, lastErr)
 		if err := waitRetryBackoff(s.parentCtx, attempt, s.callOpts); err != nil {
 			return err
 		}
 		callCtx, cancel := perCallContext(s.parentCtx, s.callOpts, attempt)
 
-		var newStream grpc.ClientStream
 		var err error
 		func() {
 			defer cancel()
-			newStream, err = s.reestablishStreamAndResendBuffer(callCtx)
+			err = s.reestablishStreamAndResendBuffer(callCtx)
 		}()
 		if err != nil {
 			// TODO(mwitkow): Maybe dial and transport errors should be retriable?
 			return err
 		}
-		s.setStream(newStream)
 		attemptRetry, lastErr = s.receiveMsgAndIndicateRetry(m)
 		//fmt.Printf("Received message and indicate: %v  %v\n", attemptRetry, lastErr)
 		if !attemptRetry {
@@ -341,6 +452,7 @@ func (s *serverStreamingRetryingStream) receiveMsgAndIndicateRetry(m interface{}
 	if err == nil || err == io.EOF {
 		s.mu.Lock()
 		s.receivedGood = true
+		s.bufferedSends = nil
 		s.mu.Unlock()
 		return false, err
 	} else if wasGood {
@@ -359,23 +471,35 @@ func (s *serverStreamingRetryingStream) receiveMsgAndIndicateRetry(m interface{}
 	return isRetriable(err, s.callOpts), err
 }
 
-func (s *serverStreamingRetryingStream) reestablishStreamAndResendBuffer(callCtx context.Context) (grpc.ClientStream, error) {
+// reestablishStreamAndResendBuffer creates a new stream, replays the buffered messages on it
+// and sets it as the current stream.
+//
+// Sends are blocked until it returns, so that they're not lost on the previous stream.
+func (s *serverStreamingRetryingStream) reestablishStreamAndResendBuffer(callCtx context.Context) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
 	s.mu.RLock()
 	bufferedSends := s.bufferedSends
+	wasClosedSend := s.wasClosedSend
 	s.mu.RUnlock()
 	newStream, err := s.streamerCall(callCtx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for _, msg := range bufferedSends {
 		if err := newStream.SendMsg(msg); err != nil {
-			return nil, err
+			return err
 		}
 	}
-	if err := newStream.CloseSend(); err != nil {
-		return nil, err
+	// client streams may still be sending, so only close if the client already has.
+	if !s.clientStreams || wasClosedSend {
+		if err := newStream.CloseSend(); err != nil {
+			return err
+		}
 	}
-	return newStream, nil
+	s.setStream(newStream)
+	return nil
 }
 
 func waitRetryBackoff(parentCtx context.Context, attempt uint, callOpts *retryOptions) error {
@@ -384,6 +508,11 @@ func waitRetryBackoff(parentCtx context.Context, attempt uint, callOpts *retryOp
 		waitTime = callOpts.backoffFunc(parentCtx, attempt)
 	}
 	if waitTime > 0 {
+		// if the parent context would expire before the backoff finishes there is
+		// no time left for another attempt, so give up now rather than sleep.
+		if deadline, ok := parentCtx.Deadline(); ok && time.Until(deadline) <= waitTime {
+			return contextErrToGrpcErr(context.DeadlineExceeded)
+		}
 		timer := time.NewTimer(waitTime)
 		select {
 		case <-parentCtx.Done():
@@ -395,6 +524,12 @@ func waitRetryBackoff(parentCtx context.Context, attempt uint, callOpts *retryOp
 	return nil
 }
 
+func notifyRetry(callOpts *retryOptions, attempt uint, err error) {
+	if callOpts.onRetry != nil {
+		callOpts.onRetry(attempt, err)
+	}
+}
+
 func isRetriable(err error, callOpts *retryOptions) bool {
 	if isContextError(err) {
 		return false
@@ -406,6 +541,9 @@ func isRetriable(err error, callOpts *retryOptions) bool {
 			return true
 		}
 	}
+	if callOpts.retryOn != nil && callOpts.retryOn(err) {
+		return true
+	}
 	return !callOpts.abortOnFailure
 }
 
@@ -423,6 +561,9 @@ func perCallContext(parentCtx context.Context, callOpts *retryOptions, attempt u
 	if attempt > 0 && callOpts.includeHeader {
 		mdClone := cloneMetadata(extractOutgoingMetadata(ctx))
 		mdClone = setMetadata(mdClone, MetadataKeyAttempt, fmt.Sprintf("%d", attempt))
+		if callOpts.includeLegacyHeader {
+			mdClone = setMetadata(mdClone, MetadataKeyAttemptLegacy, fmt.Sprintf("%d", attempt))
+		}
 		ctx = toOutgoing(ctx, mdClone)
 	}
 	return
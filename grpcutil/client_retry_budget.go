@@ -0,0 +1,87 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import "sync"
+
+// WithRetryBudget throttles retries with a token bucket shared by every call
+// made through this option, i.e. every call on the interceptor it is passed to.
+//
+// Each call deposits `ratio` tokens into the bucket, and each retry withdraws
+// a whole token; retries are only attempted while a token is available. The
+// bucket holds at most `minRetries` tokens (and at least one), and starts full,
+// so `minRetries` bounds the burst of retries available to a healthy client,
+// and `ratio` bounds the sustained ratio of retries to calls.
+//
+// When the budget is exhausted the interceptor returns the last error without retrying.
+func WithRetryBudget(ratio float64, minRetries int) CallOption {
+	budget := newRetryBudget(ratio, minRetries)
+	return CallOption{applyFunc: func(o *retryOptions) {
+		o.budget = budget
+	}}
+}
+
+func newRetryBudget(ratio float64, minRetries int) *retryBudget {
+	maxTokens := float64(minRetries)
+	if maxTokens < 1 {
+		maxTokens = 1
+	}
+	if ratio < 0 {
+		ratio = 0
+	}
+	return &retryBudget{
+		ratio:     ratio,
+		maxTokens: maxTokens,
+		tokens:    maxTokens,
+	}
+}
+
+// retryBudget is a concurrency safe token bucket that limits retries.
+type retryBudget struct {
+	mu        sync.Mutex
+	ratio     float64
+	maxTokens float64
+	tokens    float64
+}
+
+// deposit adds the per call ratio of tokens to the bucket.
+func (rb *retryBudget) deposit() {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.tokens += rb.ratio
+	if rb.tokens > rb.maxTokens {
+		rb.tokens = rb.maxTokens
+	}
+}
+
+// withdraw removes a token from the bucket, returning
+// if there was a token available to withdraw.
+func (rb *retryBudget) withdraw() bool {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if rb.tokens < 1 {
+		return false
+	}
+	rb.tokens--
+	return true
+}
+
+// retryBudgetDeposit deposits into the budget if one is configured.
+func retryBudgetDeposit(callOpts *retryOptions) {
+	if callOpts.budget != nil {
+		callOpts.budget.deposit()
+	}
+}
+
+// retryBudgetAllows returns if the budget, if one is configured, allows a retry.
+func retryBudgetAllows(callOpts *retryOptions) bool {
+	if callOpts.budget == nil {
+		return true
+	}
+	return callOpts.budget.withdraw()
+}
@@ -0,0 +1,129 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestRetryBudget(t *testing.T) {
+	assert := assert.New(t)
+
+	rb := newRetryBudget(0.5, 2)
+	assert.True(rb.withdraw())
+	assert.True(rb.withdraw())
+	assert.False(rb.withdraw())
+
+	rb.deposit()
+	assert.False(rb.withdraw())
+	rb.deposit()
+	assert.True(rb.withdraw())
+	assert.False(rb.withdraw())
+
+	// the bucket is capped
+	for x := 0; x < 10; x++ {
+		rb.deposit()
+	}
+	assert.True(rb.withdraw())
+	assert.True(rb.withdraw())
+	assert.False(rb.withdraw())
+
+	// there is always room for at least one token
+	rb = newRetryBudget(1, 0)
+	assert.True(rb.withdraw())
+	assert.False(rb.withdraw())
+}
+
+func TestRetryBudgetConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	rb := newRetryBudget(0, 100)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var withdrawn int
+	for x := 0; x < 10; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := 0; y < 20; y++ {
+				if rb.withdraw() {
+					mu.Lock()
+					withdrawn++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(100, withdrawn)
+}
+
+func TestRetryUnaryClientInterceptorRetryBudget(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	interceptor := RetryUnaryClientInterceptor(
+		WithClientRetries(5),
+		WithClientRetryBackoffLinear(0),
+		WithRetryBudget(0, 3),
+	)
+
+	// the first call spends the whole budget
+	err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+	assert.Equal(codes.Unavailable, status.Code(err))
+	assert.Equal(4, calls)
+
+	// the budget is shared, so subsequent calls are not retried
+	calls = 0
+	err = interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+	assert.Equal(codes.Unavailable, status.Code(err))
+	assert.Equal(1, calls)
+}
+
+func TestRetryUnaryClientInterceptorRetryBudgetLastAttempt(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	interceptor := RetryUnaryClientInterceptor(
+		WithClientRetries(2),
+		WithClientRetryBackoffLinear(0),
+		WithRetryBudget(0, 3),
+	)
+
+	// each call retries once, and a failed final attempt doesn't spend the budget,
+	// so the budget covers three calls.
+	for i := 0; i < 3; i++ {
+		calls = 0
+		err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+		assert.Equal(codes.Unavailable, status.Code(err))
+		assert.Equal(2, calls)
+	}
+
+	calls = 0
+	err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+	assert.Equal(codes.Unavailable, status.Code(err))
+	assert.Equal(1, calls)
+}
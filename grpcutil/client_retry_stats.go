@@ -0,0 +1,70 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/blend/go-sdk/stats"
+)
+
+// Retry stats constants.
+const (
+	MetricNameClientRetry = "grpc.client.retry"
+
+	TagClientRetryMethod = "rpc_method"
+	TagClientRetryCode   = "rpc_code"
+)
+
+// StatsCollector is the subset of a stats collector the retry interceptors emit to.
+//
+// It is satisfied by `stats.Collector`.
+type StatsCollector interface {
+	Increment(name string, tags ...string) error
+}
+
+// RetryUnaryClientInterceptorWithStats returns a new retrying unary client interceptor
+// that increments `grpc.client.retry` on the collector for each retry a call consumed,
+// tagged with the method and the final code of the call.
+//
+// The options are the same as for `RetryUnaryClientInterceptor`.
+func RetryUnaryClientInterceptorWithStats(collector StatsCollector, optFuncs ...CallOption) grpc.UnaryClientInterceptor {
+	retry := RetryUnaryClientInterceptor(optFuncs...)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var retries uint
+		err := retry(ctx, method, req, reply, cc, invoker, append(opts, withRetryCounter(&retries))...)
+		if collector == nil {
+			return err
+		}
+		tags := []string{
+			stats.Tag(TagClientRetryMethod, method),
+			stats.Tag(TagClientRetryCode, status.Code(err).String()),
+		}
+		for x := uint(0); x < retries; x++ {
+			_ = collector.Increment(MetricNameClientRetry, tags...)
+		}
+		return err
+	}
+}
+
+// withRetryCounter records the number of retries into the given counter,
+// preserving any existing `WithOnRetry` callback.
+func withRetryCounter(retries *uint) CallOption {
+	return CallOption{applyFunc: func(o *retryOptions) {
+		onRetry := o.onRetry
+		o.onRetry = func(attempt uint, err error) {
+			*retries = attempt
+			if onRetry != nil {
+				onRetry(attempt, err)
+			}
+		}
+	}}
+}
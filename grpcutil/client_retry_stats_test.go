@@ -0,0 +1,67 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/stats"
+)
+
+func TestRetryUnaryClientInterceptorWithStats(t *testing.T) {
+	assert := assert.New(t)
+
+	collector := stats.NewMockCollector(32)
+	var onRetryCalls int
+	interceptor := RetryUnaryClientInterceptorWithStats(collector,
+		WithClientRetries(5),
+		WithClientRetryBackoffLinear(0),
+		WithOnRetry(func(_ uint, _ error) { onRetryCalls++ }),
+	)
+
+	var calls int
+	err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, failingInvoker(&calls,
+		status.Error(codes.Unavailable, "first"),
+		status.Error(codes.Unavailable, "second"),
+	))
+	assert.Nil(err)
+	assert.Equal(3, calls)
+	assert.Equal(2, onRetryCalls, "existing retry callbacks are still called")
+
+	metrics := collector.AllMetrics()
+	assert.Len(metrics, 2)
+	for _, metric := range metrics {
+		assert.Equal(MetricNameClientRetry, metric.Name)
+		assert.Equal([]string{"rpc_method:/test.Service/Method", "rpc_code:OK"}, metric.Tags)
+	}
+
+	// calls that are not retried do not emit anything
+	calls = 0
+	err = interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, failingInvoker(&calls))
+	assert.Nil(err)
+	assert.Empty(collector.AllMetrics())
+
+	// the final code is tagged
+	calls = 0
+	err = interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, failingInvoker(&calls,
+		status.Error(codes.Unavailable, "first"),
+		status.Error(codes.ResourceExhausted, "second"),
+		status.Error(codes.ResourceExhausted, "third"),
+		status.Error(codes.ResourceExhausted, "fourth"),
+		status.Error(codes.ResourceExhausted, "fifth"),
+	))
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+	metrics = collector.AllMetrics()
+	assert.Len(metrics, 4)
+	assert.Equal([]string{"rpc_method:/test.Service/Method", "rpc_code:ResourceExhausted"}, metrics[0].Tags)
+}
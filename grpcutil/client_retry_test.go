@@ -0,0 +1,384 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+// failingInvoker returns a unary invoker that fails with the given errors in order, then succeeds.
+func failingInvoker(calls *int, errs ...error) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		defer func() { *calls++ }()
+		if *calls < len(errs) {
+			return errs[*calls]
+		}
+		return nil
+	}
+}
+
+func TestRetryUnaryClientInterceptorOnRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	var attempts []uint
+	var errs []error
+	interceptor := RetryUnaryClientInterceptor(
+		WithClientRetries(5),
+		WithClientRetryBackoffLinear(0),
+		WithOnRetry(func(attempt uint, err error) {
+			attempts = append(attempts, attempt)
+			errs = append(errs, err)
+		}),
+	)
+
+	var calls int
+	err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, failingInvoker(&calls,
+		status.Error(codes.Unavailable, "first"),
+		status.Error(codes.ResourceExhausted, "second"),
+	))
+	assert.Nil(err)
+	assert.Equal(3, calls)
+	assert.Equal([]uint{1, 2}, attempts)
+	assert.Len(errs, 2)
+	assert.Equal("first", status.Convert(errs[0]).Message())
+	assert.Equal("second", status.Convert(errs[1]).Message())
+}
+
+func TestRetryUnaryClientInterceptorBackoffPastDeadline(t *testing.T) {
+	assert := assert.New(t)
+
+	interceptor := RetryUnaryClientInterceptor(
+		WithClientRetries(5),
+		WithClientRetryBackoffLinear(time.Minute),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	var calls int
+	started := time.Now()
+	err := interceptor(ctx, "/test.Service/Method", nil, nil, nil, failingInvoker(&calls,
+		status.Error(codes.Unavailable, "first"),
+		status.Error(codes.Unavailable, "second"),
+	))
+	assert.True(time.Since(started) < 250*time.Millisecond, "should return before the deadline")
+	assert.Equal(codes.DeadlineExceeded, status.Code(err))
+	// the first retry is immediate, the second would back off past the deadline.
+	assert.Equal(2, calls)
+}
+
+func TestWaitRetryBackoffDeadline(t *testing.T) {
+	assert := assert.New(t)
+
+	callOpts := &retryOptions{
+		backoffFunc: func(_ context.Context, _ uint) time.Duration { return 50 * time.Millisecond },
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	assert.Nil(waitRetryBackoff(ctx, 1, callOpts))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err := waitRetryBackoff(ctx, 1, callOpts)
+	assert.True(time.Since(started) < 10*time.Millisecond, "should not sleep")
+	assert.Equal(codes.DeadlineExceeded, status.Code(err))
+
+	assert.Nil(waitRetryBackoff(context.Background(), 1, callOpts))
+}
+
+func TestRetryUnaryClientInterceptorOnRetryCallOption(t *testing.T) {
+	assert := assert.New(t)
+
+	interceptor := RetryUnaryClientInterceptor(
+		WithClientRetries(3),
+		WithClientRetryBackoffLinear(0),
+	)
+
+	var retries uint
+	var calls int
+	err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, failingInvoker(&calls,
+		status.Error(codes.Unavailable, "first"),
+		status.Error(codes.Unavailable, "second"),
+		status.Error(codes.Unavailable, "third"),
+	), WithOnRetry(func(attempt uint, _ error) { retries = attempt }))
+	assert.NotNil(err)
+	assert.Equal("third", status.Convert(err).Message(), fmt.Sprint(err))
+	assert.Equal(3, calls)
+	assert.Equal(2, retries)
+}
+
+func TestIsRetriableRetryOn(t *testing.T) {
+	assert := assert.New(t)
+
+	retryOn := WithClientRetryOn(func(err error) bool {
+		return status.Convert(err).Message() == "retry me"
+	})
+	abort := WithClientRetryOnlyListedCodes()
+
+	opts := reuseOrNewWithCallOptions(defaultRetryOptions, []CallOption{retryOn, abort})
+	assert.True(isRetriable(status.Error(codes.Unavailable, "listed code"), opts))
+	assert.True(isRetriable(status.Error(codes.Internal, "retry me"), opts))
+	assert.False(isRetriable(status.Error(codes.Internal, "do not retry me"), opts))
+	assert.False(isRetriable(status.Error(codes.Canceled, "retry me"), opts))
+
+	// without WithClientRetryOnlyListedCodes, unmatched errors are still retried
+	opts = reuseOrNewWithCallOptions(defaultRetryOptions, []CallOption{retryOn})
+	assert.True(isRetriable(status.Error(codes.Internal, "do not retry me"), opts))
+}
+
+func TestRetryUnaryClientInterceptorOnlyListedCodes(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	interceptor := RetryUnaryClientInterceptor(
+		WithClientRetries(3),
+		WithClientRetryBackoffLinear(0),
+		WithClientRetryOnlyListedCodes(),
+	)
+	err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, failingInvoker(&calls,
+		status.Error(codes.Unavailable, "listed"),
+		status.Error(codes.Internal, "unlisted"),
+	))
+	assert.Equal(codes.Internal, status.Code(err))
+	assert.Equal(2, calls)
+
+	// by default unlisted codes are retried
+	calls = 0
+	interceptor = RetryUnaryClientInterceptor(
+		WithClientRetries(3),
+		WithClientRetryBackoffLinear(0),
+	)
+	err = interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, failingInvoker(&calls,
+		status.Error(codes.Unavailable, "listed"),
+		status.Error(codes.Internal, "unlisted"),
+	))
+	assert.Nil(err)
+	assert.Equal(3, calls)
+}
+
+// mockClientStream is a grpc.ClientStream that records sent messages.
+type mockClientStream struct {
+	grpc.ClientStream
+	sent       []interface{}
+	closedSend bool
+	recvErr    error
+}
+
+func (mcs *mockClientStream) SendMsg(m interface{}) error {
+	mcs.sent = append(mcs.sent, m)
+	return nil
+}
+
+func (mcs *mockClientStream) CloseSend() error {
+	mcs.closedSend = true
+	return nil
+}
+
+func (mcs *mockClientStream) RecvMsg(m interface{}) error {
+	return mcs.recvErr
+}
+
+func TestRetryStreamClientInterceptorClientStreams(t *testing.T) {
+	assert := assert.New(t)
+
+	desc := &grpc.StreamDesc{ClientStreams: true}
+	var streams []*mockClientStream
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream := new(mockClientStream)
+		if len(streams) == 0 {
+			stream.recvErr = status.Error(codes.Unavailable, "unavailable")
+		}
+		streams = append(streams, stream)
+		return stream, nil
+	}
+
+	// client streams are not retried by default
+	_, err := RetryStreamClientInterceptor(WithClientRetries(3))(context.Background(), desc, nil, "/test.Service/Method", streamer)
+	assert.Equal(codes.Unimplemented, status.Code(err))
+
+	interceptor := RetryStreamClientInterceptor(
+		WithClientRetries(3),
+		WithClientRetryBackoffLinear(0),
+		WithClientStreamRetryBuffer(2),
+	)
+	stream, err := interceptor(context.Background(), desc, nil, "/test.Service/Method", streamer)
+	assert.Nil(err)
+	assert.Nil(stream.SendMsg("one"))
+	assert.Nil(stream.SendMsg("two"))
+	assert.Nil(stream.CloseSend())
+	assert.Nil(stream.RecvMsg(nil))
+
+	assert.Len(streams, 2)
+	assert.Equal([]interface{}{"one", "two"}, streams[1].sent)
+	assert.True(streams[1].closedSend)
+}
+
+func TestRetryStreamClientInterceptorClientStreamsBufferExceeded(t *testing.T) {
+	assert := assert.New(t)
+
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return new(mockClientStream), nil
+	}
+
+	interceptor := RetryStreamClientInterceptor(
+		WithClientRetries(3),
+		WithClientStreamRetryBuffer(1),
+	)
+	stream, err := interceptor(context.Background(), desc, nil, "/test.Service/Method", streamer)
+	assert.Nil(err)
+	assert.Nil(stream.SendMsg("one"))
+	assert.Equal(codes.ResourceExhausted, status.Code(stream.SendMsg("two")))
+}
+
+func TestRetryStreamClientInterceptorClientStreamsBufferAfterReceive(t *testing.T) {
+	assert := assert.New(t)
+
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return new(mockClientStream), nil
+	}
+
+	interceptor := RetryStreamClientInterceptor(
+		WithClientRetries(3),
+		WithClientStreamRetryBuffer(1),
+	)
+	stream, err := interceptor(context.Background(), desc, nil, "/test.Service/Method", streamer)
+	assert.Nil(err)
+	assert.Nil(stream.SendMsg("one"))
+	assert.Nil(stream.RecvMsg(nil))
+	// the stream can no longer be retried, so the buffer limit no longer applies.
+	assert.Nil(stream.SendMsg("two"))
+	assert.Nil(stream.SendMsg("three"))
+}
+
+func TestRetryStreamClientInterceptorClientStreamsSendDuringRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	var mu sync.Mutex
+	var streams []*mockClientStream
+	reconnecting := make(chan struct{})
+	reconnect := make(chan struct{})
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream := new(mockClientStream)
+		mu.Lock()
+		if len(streams) == 0 {
+			stream.recvErr = status.Error(codes.Unavailable, "unavailable")
+		}
+		streams = append(streams, stream)
+		count := len(streams)
+		mu.Unlock()
+		if count == 2 {
+			close(reconnecting)
+			<-reconnect
+		}
+		return stream, nil
+	}
+
+	interceptor := RetryStreamClientInterceptor(
+		WithClientRetries(3),
+		WithClientRetryBackoffLinear(0),
+		WithClientStreamRetryBuffer(10),
+	)
+	stream, err := interceptor(context.Background(), desc, nil, "/test.Service/Method", streamer)
+	assert.Nil(err)
+	assert.Nil(stream.SendMsg("one"))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.Nil(stream.RecvMsg(nil))
+	}()
+	<-reconnecting
+	go func() {
+		defer wg.Done()
+		assert.Nil(stream.SendMsg("two"))
+	}()
+	// give the send a chance to reach the previous stream if it isn't blocked.
+	time.Sleep(10 * time.Millisecond)
+	close(reconnect)
+	wg.Wait()
+
+	assert.Len(streams, 2)
+	assert.Equal([]interface{}{"one"}, streams[0].sent)
+	assert.Equal([]interface{}{"one", "two"}, streams[1].sent)
+}
+
+func TestRetryStreamClientInterceptorBidiStreamsOpenSend(t *testing.T) {
+	assert := assert.New(t)
+
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	var streams []*mockClientStream
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream := new(mockClientStream)
+		if len(streams) == 0 {
+			stream.recvErr = status.Error(codes.Unavailable, "unavailable")
+		}
+		streams = append(streams, stream)
+		return stream, nil
+	}
+
+	interceptor := RetryStreamClientInterceptor(
+		WithClientRetries(3),
+		WithClientRetryBackoffLinear(0),
+		WithClientStreamRetryBuffer(10),
+	)
+	stream, err := interceptor(context.Background(), desc, nil, "/test.Service/Method", streamer)
+	assert.Nil(err)
+	assert.Nil(stream.SendMsg("one"))
+	assert.Nil(stream.RecvMsg(nil))
+
+	// the replacement stream is left open for sending
+	assert.Len(streams, 2)
+	assert.Equal([]interface{}{"one"}, streams[1].sent)
+	assert.False(streams[1].closedSend)
+	assert.Nil(stream.SendMsg("two"))
+	assert.Equal([]interface{}{"one", "two"}, streams[1].sent)
+}
+
+func TestRetryUnaryClientInterceptorAttemptMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	var outgoing []metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		outgoing = append(outgoing, md)
+		if len(outgoing) == 1 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+
+	interceptor := RetryUnaryClientInterceptor(WithClientRetries(3), WithClientRetryBackoffLinear(0))
+	assert.Nil(interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker))
+	assert.Len(outgoing, 2)
+	assert.Empty(outgoing[0].Get(MetadataKeyAttempt))
+	assert.Equal([]string{"1"}, outgoing[1].Get("x-retry-attempt"))
+	assert.Empty(outgoing[1].Get(MetadataKeyAttemptLegacy))
+
+	outgoing = nil
+	assert.Nil(interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker, WithLegacyAttemptHeader()))
+	assert.Len(outgoing, 2)
+	assert.Equal([]string{"1"}, outgoing[1].Get("x-retry-attempt"))
+	assert.Equal([]string{"1"}, outgoing[1].Get("x-retry-attempty"))
+}
@@ -0,0 +1,159 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/blend/go-sdk/certutil"
+)
+
+// Dial defaults
+const (
+	// DefaultDialRetries is the default number of times unary calls are retried.
+	DefaultDialRetries = 3
+	// DefaultDialKeepaliveTime is the default time after which a keepalive ping is sent on an idle connection.
+	//
+	// It matches the minimum ping interval servers permit by default; pinging more often
+	// can cause servers to close the connection.
+	DefaultDialKeepaliveTime = 5 * time.Minute
+	// DefaultDialKeepaliveTimeout is the default time to wait for a keepalive ping to be acknowledged.
+	DefaultDialKeepaliveTimeout = 20 * time.Second
+)
+
+// Dial dials a target with the retry interceptors, keepalive parameters and (optional) tls credentials
+// set by the given options.
+//
+// By default, unary calls are retried `DefaultDialRetries` times with the default retry options,
+// keepalive pings are sent after `DefaultDialKeepaliveTime`, and the connection is insecure
+// unless a tls config is set with `OptDialTLSConfig` or `OptDialClientCert`.
+//
+// Stream calls are not retried unless retries are set with `OptDialRetryOptions`; client and
+// bidi streams also need a buffer for sent messages set with `WithClientStreamRetryBuffer`.
+func Dial(target string, opts ...DialOption) (*grpc.ClientConn, error) {
+	options := DialOptions{
+		UnaryRetryOptions: []CallOption{
+			WithClientRetries(DefaultDialRetries),
+		},
+		Keepalive: keepalive.ClientParameters{
+			Time:    DefaultDialKeepaliveTime,
+			Timeout: DefaultDialKeepaliveTimeout,
+		},
+	}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return nil, err
+		}
+	}
+	return DialAddress(target, options.GRPCDialOptions()...)
+}
+
+// DialOption mutates dial options.
+type DialOption func(*DialOptions) error
+
+// OptDialRetryOptions adds retry options for both the unary and stream retry interceptors.
+//
+// They are applied after the defaults, e.g. `WithRetriesDisabled()` disables retries.
+func OptDialRetryOptions(opts ...CallOption) DialOption {
+	return func(do *DialOptions) error {
+		do.RetryOptions = append(do.RetryOptions, opts...)
+		return nil
+	}
+}
+
+// OptDialKeepalive sets the keepalive parameters.
+func OptDialKeepalive(params keepalive.ClientParameters) DialOption {
+	return func(do *DialOptions) error {
+		do.Keepalive = params
+		return nil
+	}
+}
+
+// OptDialTLSConfig sets the tls config used for the transport credentials.
+func OptDialTLSConfig(config *tls.Config) DialOption {
+	return func(do *DialOptions) error {
+		do.TLSConfig = config
+		return nil
+	}
+}
+
+// OptDialClientCert sets the tls config to a mutual tls config for a given client
+// cert and certificate authorities (see `certutil.NewClientTLSConfig`).
+func OptDialClientCert(clientCert certutil.KeyPair, certificateAuthorities ...certutil.KeyPair) DialOption {
+	return func(do *DialOptions) error {
+		config, err := certutil.NewClientTLSConfig(clientCert, certificateAuthorities)
+		if err != nil {
+			return err
+		}
+		do.TLSConfig = config
+		return nil
+	}
+}
+
+// OptDialUnaryInterceptors adds unary interceptors, which run before the retry interceptor.
+func OptDialUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) DialOption {
+	return func(do *DialOptions) error {
+		do.UnaryInterceptors = append(do.UnaryInterceptors, interceptors...)
+		return nil
+	}
+}
+
+// OptDialStreamInterceptors adds stream interceptors, which run before the retry interceptor.
+func OptDialStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) DialOption {
+	return func(do *DialOptions) error {
+		do.StreamInterceptors = append(do.StreamInterceptors, interceptors...)
+		return nil
+	}
+}
+
+// OptDialGRPCOptions adds grpc dial options, which are applied after the options set by `Dial`.
+func OptDialGRPCOptions(opts ...grpc.DialOption) DialOption {
+	return func(do *DialOptions) error {
+		do.GRPCOptions = append(do.GRPCOptions, opts...)
+		return nil
+	}
+}
+
+// DialOptions are the options used by `Dial`.
+//
+// `UnaryRetryOptions` are only used by the unary retry interceptor, and are applied
+// before `RetryOptions`, which are used by both the unary and stream retry interceptors.
+type DialOptions struct {
+	UnaryRetryOptions  []CallOption
+	RetryOptions       []CallOption
+	Keepalive          keepalive.ClientParameters
+	TLSConfig          *tls.Config
+	UnaryInterceptors  []grpc.UnaryClientInterceptor
+	StreamInterceptors []grpc.StreamClientInterceptor
+	GRPCOptions        []grpc.DialOption
+}
+
+// GRPCDialOptions returns the grpc dial options for the options.
+func (do DialOptions) GRPCDialOptions() []grpc.DialOption {
+	unaryRetryOptions := append(append([]CallOption{}, do.UnaryRetryOptions...), do.RetryOptions...)
+	unaryInterceptors := append(append([]grpc.UnaryClientInterceptor{}, do.UnaryInterceptors...), RetryUnaryClientInterceptor(unaryRetryOptions...))
+	streamInterceptors := append(append([]grpc.StreamClientInterceptor{}, do.StreamInterceptors...), RetryStreamClientInterceptor(do.RetryOptions...))
+
+	var output []grpc.DialOption
+	if do.TLSConfig != nil {
+		output = append(output, grpc.WithTransportCredentials(credentials.NewTLS(do.TLSConfig)))
+	} else {
+		output = append(output, grpc.WithInsecure())
+	}
+	output = append(output,
+		grpc.WithKeepaliveParams(do.Keepalive),
+		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
+		grpc.WithChainStreamInterceptor(streamInterceptors...),
+	)
+	return append(output, do.GRPCOptions...)
+}
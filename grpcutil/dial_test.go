@@ -0,0 +1,176 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/certutil"
+)
+
+func TestDial(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int32
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return nil, status.Error(codes.Unavailable, "unavailable")
+		}
+		return handler(ctx, req)
+	}))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	var intercepted int32
+	conn, err := Dial(listener.Addr().String(),
+		OptDialRetryOptions(WithClientRetryBackoffLinear(time.Millisecond)),
+		OptDialUnaryInterceptors(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			atomic.AddInt32(&intercepted, 1)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+	assert.Nil(err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, new(grpc_health_v1.HealthCheckRequest))
+	assert.Nil(err)
+	assert.Equal(grpc_health_v1.HealthCheckResponse_SERVING, res.Status)
+	assert.Equal(2, atomic.LoadInt32(&calls))
+	assert.Equal(1, atomic.LoadInt32(&intercepted))
+}
+
+func TestDialRetriesDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int32
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := Dial(listener.Addr().String(), OptDialRetryOptions(WithRetriesDisabled()))
+	assert.Nil(err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, new(grpc_health_v1.HealthCheckRequest))
+	assert.Equal(codes.Unavailable, status.Code(err))
+	assert.Equal(1, atomic.LoadInt32(&calls))
+}
+
+// echoStreams is an unknown service handler that responds once to client streams,
+// and once per message to bidi streams.
+func echoStreams(_ interface{}, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	var received int
+	for {
+		if err := stream.RecvMsg(new(grpc_health_v1.HealthCheckRequest)); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		received++
+		if method == "/test.Echo/Bidi" {
+			if err := stream.SendMsg(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}); err != nil {
+				return err
+			}
+		}
+	}
+	if method == "/test.Echo/Bidi" {
+		return nil
+	}
+	if received == 0 {
+		return status.Error(codes.InvalidArgument, "no messages")
+	}
+	return stream.SendMsg(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+func TestDialStreams(t *testing.T) {
+	assert := assert.New(t)
+
+	server := grpc.NewServer(grpc.UnknownServiceHandler(echoStreams))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := Dial(listener.Addr().String())
+	assert.Nil(err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	clientStream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true}, "/test.Echo/Client")
+	assert.Nil(err)
+	for x := 0; x < 3; x++ {
+		assert.Nil(clientStream.SendMsg(new(grpc_health_v1.HealthCheckRequest)))
+	}
+	assert.Nil(clientStream.CloseSend())
+	res := new(grpc_health_v1.HealthCheckResponse)
+	assert.Nil(clientStream.RecvMsg(res))
+	assert.Equal(grpc_health_v1.HealthCheckResponse_SERVING, res.Status)
+
+	bidiStream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}, "/test.Echo/Bidi")
+	assert.Nil(err)
+	for x := 0; x < 3; x++ {
+		assert.Nil(bidiStream.SendMsg(new(grpc_health_v1.HealthCheckRequest)))
+		res = new(grpc_health_v1.HealthCheckResponse)
+		assert.Nil(bidiStream.RecvMsg(res))
+		assert.Equal(grpc_health_v1.HealthCheckResponse_SERVING, res.Status)
+	}
+	assert.Nil(bidiStream.CloseSend())
+	assert.Equal(io.EOF, bidiStream.RecvMsg(new(grpc_health_v1.HealthCheckResponse)))
+}
+
+func TestDialOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	var options DialOptions
+	params := keepalive.ClientParameters{Time: time.Minute, Timeout: time.Second}
+	assert.Nil(OptDialKeepalive(params)(&options))
+	assert.Equal(params, options.Keepalive)
+
+	assert.Nil(OptDialRetryOptions(WithClientRetries(5), WithRetriesDisabled())(&options))
+	assert.Len(options.RetryOptions, 2)
+
+	assert.Nil(OptDialGRPCOptions(grpc.WithBlock())(&options))
+	assert.Len(options.GRPCOptions, 1)
+	// credentials, keepalive, the interceptor chains and the extra option
+	assert.Len(options.GRPCDialOptions(), 5)
+
+	assert.NotNil(OptDialClientCert(certutil.KeyPair{})(&options))
+	assert.Nil(options.TLSConfig)
+}
@@ -0,0 +1,72 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// AppendOutgoing returns a context with the given key value pairs appended to its outgoing metadata.
+//
+// Keys are lowercased and values for keys with the `-bin` suffix are base64 encoded.
+// The metadata of the input context is not modified. It panics if it is given an odd number of arguments.
+func AppendOutgoing(ctx context.Context, kv ...string) context.Context {
+	if len(kv)%2 == 1 {
+		panic(fmt.Sprintf("grpcutil; AppendOutgoing got an odd number of input pairs for metadata: %d", len(kv)))
+	}
+	md := cloneMetadata(extractOutgoingMetadata(ctx))
+	for i := 0; i < len(kv); i += 2 {
+		k, v := encodeMetadataKeyValue(kv[i], kv[i+1])
+		md[k] = append(md[k], v)
+	}
+	return toOutgoing(ctx, md)
+}
+
+// SetOutgoing returns a context with a given key in its outgoing metadata set to a single value,
+// replacing any existing values.
+//
+// Keys are lowercased and values for keys with the `-bin` suffix are base64 encoded.
+// The metadata of the input context is not modified.
+func SetOutgoing(ctx context.Context, key, value string) context.Context {
+	return toOutgoing(ctx, setMetadata(cloneMetadata(extractOutgoingMetadata(ctx)), key, value))
+}
+
+// GetOutgoing returns the values for a given key in the outgoing metadata of a context.
+//
+// Values for keys with the `-bin` suffix are base64 decoded.
+func GetOutgoing(ctx context.Context, key string) []string {
+	key = strings.ToLower(key)
+	values := extractOutgoingMetadata(ctx)[key]
+	if len(values) == 0 {
+		return nil
+	}
+	output := make([]string, len(values))
+	for i, value := range values {
+		output[i] = decodeMetadataValue(key, value)
+	}
+	return output
+}
+
+// decodeMetadataValue decodes a value encoded by `encodeMetadataKeyValue`.
+//
+// Values that aren't valid base64 are returned as is.
+func decodeMetadataValue(k, v string) string {
+	if !strings.HasSuffix(k, binHdrSuffix) {
+		return v
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(v); err == nil {
+		return string(decoded)
+	}
+	if decoded, err := base64.RawStdEncoding.DecodeString(v); err == nil {
+		return string(decoded)
+	}
+	return v
+}
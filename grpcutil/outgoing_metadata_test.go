@@ -0,0 +1,74 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestOutgoingMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	assert.Nil(GetOutgoing(ctx, "x-test"))
+
+	withAppended := AppendOutgoing(ctx, "X-Test", "one", "x-test", "two")
+	assert.Equal([]string{"one", "two"}, GetOutgoing(withAppended, "x-test"))
+	assert.Equal([]string{"one", "two"}, GetOutgoing(withAppended, "X-Test"))
+
+	withSet := SetOutgoing(withAppended, "x-test", "three")
+	assert.Equal([]string{"three"}, GetOutgoing(withSet, "x-test"))
+	assert.Equal([]string{"one", "two"}, GetOutgoing(withAppended, "x-test"), "the parent metadata should not be modified")
+
+	withOther := AppendOutgoing(withSet, "x-other", "four")
+	assert.Equal([]string{"three"}, GetOutgoing(withOther, "x-test"))
+	assert.Equal([]string{"four"}, GetOutgoing(withOther, "x-other"))
+	assert.Nil(GetOutgoing(withSet, "x-other"))
+
+	assert.NotNil(catchPanic(func() { AppendOutgoing(ctx, "x-test") }))
+}
+
+func TestOutgoingMetadataBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	value := string([]byte{0x00, 0xff, 'h', 'i'})
+
+	ctx := SetOutgoing(context.Background(), "X-Trace-Bin", value)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(ok)
+	assert.Equal([]string{base64.StdEncoding.EncodeToString([]byte(value))}, md["x-trace-bin"])
+	assert.Equal([]string{value}, GetOutgoing(ctx, "x-trace-bin"))
+
+	ctx = AppendOutgoing(ctx, "x-trace-bin", "plain")
+	md, _ = metadata.FromOutgoingContext(ctx)
+	assert.Equal(base64.StdEncoding.EncodeToString([]byte("plain")), md["x-trace-bin"][1])
+	assert.Equal([]string{value, "plain"}, GetOutgoing(ctx, "x-trace-bin"))
+
+	// values set without encoding are returned as is
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-raw-bin", "not base64!")
+	assert.Equal([]string{"not base64!"}, GetOutgoing(ctx, "x-raw-bin"))
+
+	// non binary keys are never encoded
+	ctx = SetOutgoing(context.Background(), "x-text", value)
+	md, _ = metadata.FromOutgoingContext(ctx)
+	assert.Equal([]string{value}, md["x-text"])
+}
+
+func catchPanic(action func()) (recovered interface{}) {
+	defer func() {
+		recovered = recover()
+	}()
+	action()
+	return
+}
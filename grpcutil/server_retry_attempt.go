@@ -0,0 +1,90 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type retryAttemptKey struct{}
+
+// WithRetryAttempt adds a retry attempt number to a context as a value.
+func WithRetryAttempt(ctx context.Context, attempt uint) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, attempt)
+}
+
+// GetRetryAttempt fetches the retry attempt number from the context.
+//
+// It returns zero for the first attempt, i.e. if the client didn't send the attempt metadata.
+func GetRetryAttempt(ctx context.Context) uint {
+	if typed, ok := ctx.Value(retryAttemptKey{}).(uint); ok {
+		return typed
+	}
+	return 0
+}
+
+type retryAttemptOptions struct {
+	trailer bool
+}
+
+// RetryAttemptOption is a type that provides a retry attempt interceptor option.
+type RetryAttemptOption func(*retryAttemptOptions)
+
+// WithRetryAttemptTrailer also echoes the retry attempt number back to the
+// client in the `MetadataKeyAttempt` response trailer.
+func WithRetryAttemptTrailer() RetryAttemptOption {
+	return func(o *retryAttemptOptions) {
+		o.trailer = true
+	}
+}
+
+// RetryAttemptUnaryServerInterceptor returns a unary server interceptor that reads the
+// retry attempt number the client writes into the `MetadataKeyAttempt` metadata key
+// (or `MetadataKeyAttemptLegacy`) and adds it to the handler context.
+//
+// Downstream handlers and interceptors can read it with `GetRetryAttempt`.
+func RetryAttemptUnaryServerInterceptor(opts ...RetryAttemptOption) grpc.UnaryServerInterceptor {
+	var o retryAttemptOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if attempt, ok := incomingRetryAttempt(ctx); ok {
+			ctx = WithRetryAttempt(ctx, attempt)
+			if o.trailer {
+				// this only fails if the context isn't a server transport context
+				_ = grpc.SetTrailer(ctx, metadata.Pairs(MetadataKeyAttempt, strconv.FormatUint(uint64(attempt), 10)))
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// incomingRetryAttempt returns the retry attempt number from the incoming metadata, if set.
+func incomingRetryAttempt(ctx context.Context) (uint, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	value := MetaValue(md, MetadataKeyAttempt)
+	if value == "" {
+		value = MetaValue(md, MetadataKeyAttemptLegacy)
+	}
+	if value == "" {
+		return 0, false
+	}
+	attempt, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return uint(attempt), true
+}
@@ -0,0 +1,95 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package grpcutil
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+// trailerStream is a server transport stream that records trailers.
+type trailerStream struct {
+	trailer metadata.MD
+}
+
+func (ts *trailerStream) Method() string                 { return "/test.Service/Method" }
+func (ts *trailerStream) SetHeader(_ metadata.MD) error  { return nil }
+func (ts *trailerStream) SendHeader(_ metadata.MD) error { return nil }
+func (ts *trailerStream) SetTrailer(md metadata.MD) error {
+	ts.trailer = metadata.Join(ts.trailer, md)
+	return nil
+}
+
+func TestRetryAttemptUnaryServerInterceptor(t *testing.T) {
+	assert := assert.New(t)
+
+	server := RetryAttemptUnaryServerInterceptor()
+	var seen []uint
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = append(seen, GetRetryAttempt(ctx))
+		if len(seen) < 3 {
+			return nil, status.Error(codes.Unavailable, "try again")
+		}
+		return "ok", nil
+	}
+
+	// forward the client's outgoing metadata to the server interceptor as incoming metadata.
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		_, err := server(metadata.NewIncomingContext(context.Background(), md), req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	client := RetryUnaryClientInterceptor(WithClientRetries(5), WithClientRetryBackoffLinear(0))
+	err := client(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+	assert.Nil(err)
+	assert.Equal([]uint{0, 1, 2}, seen)
+}
+
+func TestRetryAttemptUnaryServerInterceptorMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	var attempt uint
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		attempt = GetRetryAttempt(ctx)
+		return nil, nil
+	}
+
+	server := RetryAttemptUnaryServerInterceptor()
+	_, err := server(context.Background(), nil, nil, handler)
+	assert.Nil(err)
+	assert.Zero(attempt)
+
+	_, err = server(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKeyAttemptLegacy, "4")), nil, nil, handler)
+	assert.Nil(err)
+	assert.Equal(4, attempt)
+
+	_, err = server(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKeyAttempt, "not-a-number")), nil, nil, handler)
+	assert.Nil(err)
+	assert.Zero(attempt)
+
+	// the trailer is only set when requested
+	stream := new(trailerStream)
+	ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKeyAttempt, "2")), stream)
+	_, err = server(ctx, nil, nil, handler)
+	assert.Nil(err)
+	assert.Equal(2, attempt)
+	assert.Empty(stream.trailer)
+
+	_, err = RetryAttemptUnaryServerInterceptor(WithRetryAttemptTrailer())(ctx, nil, nil, handler)
+	assert.Nil(err)
+	assert.Equal(2, attempt)
+	assert.Equal([]string{"2"}, stream.trailer.Get(MetadataKeyAttempt))
+}
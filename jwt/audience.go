@@ -0,0 +1,80 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"encoding/json"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// Audience is the "aud" claim, as referenced at
+// https://tools.ietf.org/html/rfc7519#section-4.1.3
+//
+// It can be either a single string or an array of strings in JSON.
+type Audience []string
+
+// VerifyAudience compares the audience entries against cmp, returning true if any of them match.
+// If required is false, this method will return true if the value matches or is unset
+func (a Audience) VerifyAudience(cmp string, req bool) bool {
+	return verifyAudiences(a, cmp, req)
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// A single entry is serialized as a string, otherwise the entries are serialized as an array.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts both a single string and an array of strings.
+func (a *Audience) UnmarshalJSON(contents []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(contents, &raw); err != nil {
+		return err
+	}
+	switch typed := raw.(type) {
+	case nil:
+		*a = nil
+	case string:
+		*a = Audience{typed}
+	case []interface{}:
+		values := make(Audience, 0, len(typed))
+		for _, value := range typed {
+			valueString, ok := value.(string)
+			if !ok {
+				return ex.New(ErrInvalidAudience, ex.OptMessagef("invalid audience entry: %v", value))
+			}
+			values = append(values, valueString)
+		}
+		*a = values
+	default:
+		return ex.New(ErrInvalidAudience, ex.OptMessagef("invalid audience: %v", typed))
+	}
+	return nil
+}
+
+// verifyAudiences compares every audience entry, so the time taken
+// does not depend on which entry (if any) matched.
+func verifyAudiences(aud []string, cmp string, required bool) bool {
+	if len(aud) == 0 {
+		return !required
+	}
+	var matched bool
+	for _, value := range aud {
+		if SecureCompare(value, cmp) {
+			matched = true
+		}
+	}
+	return matched
+}
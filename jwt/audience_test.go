@@ -0,0 +1,79 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestAudienceJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var claims RegisteredClaims
+	assert.Nil(json.Unmarshal([]byte(`{"aud":"x"}`), &claims))
+	assert.Equal(Audience{"x"}, claims.Audience)
+
+	assert.Nil(json.Unmarshal([]byte(`{"aud":["x","y"]}`), &claims))
+	assert.Equal(Audience{"x", "y"}, claims.Audience)
+
+	assert.Nil(json.Unmarshal([]byte(`{"aud":null}`), &claims))
+	assert.Empty(claims.Audience)
+
+	err := json.Unmarshal([]byte(`{"aud":["x",1]}`), &claims)
+	assert.True(ex.Is(err, ErrInvalidAudience))
+	err = json.Unmarshal([]byte(`{"aud":1}`), &claims)
+	assert.True(ex.Is(err, ErrInvalidAudience))
+
+	contents, err := json.Marshal(RegisteredClaims{Audience: Audience{"x"}})
+	assert.Nil(err)
+	assert.Equal(`{"aud":"x"}`, string(contents))
+
+	contents, err = json.Marshal(RegisteredClaims{Audience: Audience{"x", "y"}})
+	assert.Nil(err)
+	assert.Equal(`{"aud":["x","y"]}`, string(contents))
+
+	contents, err = json.Marshal(RegisteredClaims{})
+	assert.Nil(err)
+	assert.Equal(`{}`, string(contents))
+}
+
+func TestAudienceVerifyAudience(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Audience{"x", "y"}.VerifyAudience("x", true))
+	assert.True(Audience{"x", "y"}.VerifyAudience("y", true))
+	assert.False(Audience{"x", "y"}.VerifyAudience("z", true))
+	assert.False(Audience{"x", "y"}.VerifyAudience("", false))
+	assert.True(Audience{}.VerifyAudience("x", false))
+	assert.False(Audience{}.VerifyAudience("x", true))
+
+	claims := RegisteredClaims{Audience: Audience{"x", "y"}}
+	assert.True(claims.VerifyAudience("y", true))
+	assert.False(claims.VerifyAudience("z", true))
+}
+
+func TestMapClaimsVerifyAudience(t *testing.T) {
+	assert := assert.New(t)
+
+	var claims MapClaims
+	assert.Nil(json.Unmarshal([]byte(`{"aud":"x"}`), &claims))
+	assert.True(claims.VerifyAudience("x", true))
+	assert.False(claims.VerifyAudience("y", true))
+
+	assert.Nil(json.Unmarshal([]byte(`{"aud":["x","y"]}`), &claims))
+	assert.True(claims.VerifyAudience("x", true))
+	assert.True(claims.VerifyAudience("y", true))
+	assert.False(claims.VerifyAudience("z", true))
+
+	assert.True(MapClaims{"aud": []string{"x", "y"}}.VerifyAudience("y", true))
+	assert.True(MapClaims{}.VerifyAudience("y", false))
+}
@@ -8,7 +8,6 @@ Use of this source code is governed by a MIT license that can be found in the LI
 package jwt
 
 import (
-	"crypto/subtle"
 	"time"
 
 	"github.com/blend/go-sdk/ex"
@@ -23,47 +22,76 @@ type Claims interface {
 // StandardClaims are a structured version of Claims Section, as referenced at
 // https://tools.ietf.org/html/rfc7519#section-4.1
 // See examples for how to use this with your own claim types
+//
+// The audience accepts both the single string and the multi-valued array form.
+//
+// StandardClaims is kept for compatibility; prefer RegisteredClaims, which
+// represents the time based claims as NumericDate values.
 type StandardClaims struct {
-	ID        string `json:"jti,omitempty"`
-	Audience  string `json:"aud,omitempty"`
-	ExpiresAt int64  `json:"exp,omitempty"`
-	IssuedAt  int64  `json:"iat,omitempty"`
-	Issuer    string `json:"iss,omitempty"`
-	NotBefore int64  `json:"nbf,omitempty"`
-	Subject   string `json:"sub,omitempty"`
+	ID        string   `json:"jti,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
 }
 
 // Valid asserts time based claims "exp, iat, nbf".
-// There is no accounting for clock skew.
+// There is no accounting for clock skew, use ValidWithLeeway for that.
 // As well, if any of the above claims are not in the token, it will still
 // be considered a valid claim.
 func (c StandardClaims) Valid() error {
-	now := TimeFunc().Unix()
+	return c.ValidWithLeeway(0)
+}
+
+// ValidWithLeeway asserts time based claims "exp, iat, nbf", tolerating
+// clock skew of up to the given leeway.
+// As well, if any of the above claims are not in the token, it will still
+// be considered a valid claim.
+func (c StandardClaims) ValidWithLeeway(leeway time.Duration) error {
+	if errs := c.validationErrors(leeway); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateAll asserts time based claims "exp, iat, nbf" like Valid does, but
+// instead of returning the first failure it returns every failure.
+//
+// If there is more than one failure, the returned error is an `ex.Multi`, and
+// each failure can be matched with `ex.Is`, e.g. against ErrValidationExpired.
+func (c StandardClaims) ValidateAll() error {
+	return ex.Append(nil, c.validationErrors(0)...)
+}
+
+func (c StandardClaims) validationErrors(leeway time.Duration) (errs []error) {
+	now := TimeFunc()
 
-	if !c.VerifyExpiresAt(now, false) {
-		delta := time.Unix(now, 0).Sub(time.Unix(c.ExpiresAt, 0))
-		return ex.New(ErrValidationExpired, ex.OptMessagef("token is expired by %v", delta))
+	if !c.VerifyExpiresAt(now.Add(-leeway).Unix(), false) {
+		delta := now.Sub(time.Unix(c.ExpiresAt, 0))
+		errs = append(errs, ex.New(ErrValidationExpired, ex.OptMessagef("token is expired by %v", delta)))
 	}
 
-	if !c.VerifyIssuedAt(now, false) {
-		return ex.New(ErrValidationIssued,
+	if !c.VerifyIssuedAtWithLeeway(now.Unix(), leeway, false) {
+		errs = append(errs, ex.New(ErrValidationIssued,
 			ex.OptMessagef("issued at: %s, now: %s",
 				time.Unix(c.IssuedAt, 0).Format(time.RFC3339),
-				time.Unix(now, 0).Format(time.RFC3339),
+				now.Format(time.RFC3339),
 			),
-		)
+		))
 	}
 
-	if !c.VerifyNotBefore(now, false) {
-		return ex.New(ErrValidationNotBefore)
+	if !c.VerifyNotBefore(now.Add(leeway).Unix(), false) {
+		errs = append(errs, ex.New(ErrValidationNotBefore))
 	}
-	return nil
+	return
 }
 
-// VerifyAudience compares the aud claim against cmp.
+// VerifyAudience compares the aud claim entries against cmp, returning true if any of them match.
 // If required is false, this method will return true if the value matches or is unset
 func (c *StandardClaims) VerifyAudience(cmp string, req bool) bool {
-	return verifyAud(c.Audience, cmp, req)
+	return verifyAudiences(c.Audience, cmp, req)
 }
 
 // VerifyExpiresAt compares the exp claim against cmp.
@@ -75,7 +103,14 @@ func (c *StandardClaims) VerifyExpiresAt(cmp int64, req bool) bool {
 // VerifyIssuedAt compares the iat claim against cmp.
 // If required is false, this method will return true if the value matches or is unset
 func (c *StandardClaims) VerifyIssuedAt(cmp int64, req bool) bool {
-	return verifyIat(c.IssuedAt, cmp, req)
+	return verifyIat(c.IssuedAt, cmp, 0, req)
+}
+
+// VerifyIssuedAtWithLeeway compares the iat claim against cmp, tolerating
+// tokens issued up to the given leeway in the future.
+// If required is false, this method will return true if the value matches or is unset
+func (c *StandardClaims) VerifyIssuedAtWithLeeway(cmp int64, leeway time.Duration, req bool) bool {
+	return verifyIat(c.IssuedAt, cmp, leeway, req)
 }
 
 // VerifyIssuer compares the iss claim against cmp.
@@ -94,10 +129,7 @@ func verifyAud(aud string, cmp string, required bool) bool {
 	if aud == "" {
 		return !required
 	}
-	if subtle.ConstantTimeCompare([]byte(aud), []byte(cmp)) != 0 {
-		return true
-	}
-	return false
+	return SecureCompare(aud, cmp)
 }
 
 func verifyExp(exp int64, now int64, required bool) bool {
@@ -107,21 +139,18 @@ func verifyExp(exp int64, now int64, required bool) bool {
 	return now <= exp
 }
 
-func verifyIat(iat int64, now int64, required bool) bool {
+func verifyIat(iat int64, now int64, leeway time.Duration, required bool) bool {
 	if iat == 0 {
 		return !required
 	}
-	return now >= iat
+	return time.Unix(now, 0).Add(leeway).Unix() >= iat
 }
 
 func verifyIss(iss string, cmp string, required bool) bool {
 	if iss == "" {
 		return !required
 	}
-	if subtle.ConstantTimeCompare([]byte(iss), []byte(cmp)) != 0 {
-		return true
-	}
-	return false
+	return SecureCompare(iss, cmp)
 }
 
 func verifyNbf(nbf int64, now int64, required bool) bool {
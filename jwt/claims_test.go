@@ -0,0 +1,160 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestStandardClaimsValidWithLeeway(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(1600000000, 0)
+	defer func() { TimeFunc = time.Now }()
+	TimeFunc = func() time.Time { return now }
+
+	expired := StandardClaims{ExpiresAt: now.Add(-3 * time.Second).Unix()}
+	assert.True(ex.Is(expired.Valid(), ErrValidationExpired))
+	assert.True(ex.Is(expired.ValidWithLeeway(time.Second), ErrValidationExpired))
+	assert.Nil(expired.ValidWithLeeway(5 * time.Second))
+
+	issued := StandardClaims{IssuedAt: now.Add(3 * time.Second).Unix()}
+	assert.True(ex.Is(issued.Valid(), ErrValidationIssued))
+	assert.Nil(issued.ValidWithLeeway(5 * time.Second))
+
+	notBefore := StandardClaims{NotBefore: now.Add(3 * time.Second).Unix()}
+	assert.True(ex.Is(notBefore.Valid(), ErrValidationNotBefore))
+	assert.Nil(notBefore.ValidWithLeeway(5 * time.Second))
+}
+
+func TestMapClaimsValidWithLeeway(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(1600000000, 0)
+	defer func() { TimeFunc = time.Now }()
+	TimeFunc = func() time.Time { return now }
+
+	expired := MapClaims{"exp": float64(now.Add(-3 * time.Second).Unix())}
+	assert.True(ex.Is(expired.Valid(), ErrValidationExpired))
+	assert.Nil(expired.ValidWithLeeway(5 * time.Second))
+
+	issued := MapClaims{"iat": float64(now.Add(3 * time.Second).Unix())}
+	assert.True(ex.Is(issued.Valid(), ErrValidationIssued))
+	assert.Nil(issued.ValidWithLeeway(5 * time.Second))
+
+	notBefore := MapClaims{"nbf": float64(now.Add(3 * time.Second).Unix())}
+	assert.True(ex.Is(notBefore.Valid(), ErrValidationNotBefore))
+	assert.Nil(notBefore.ValidWithLeeway(5 * time.Second))
+}
+
+func TestStandardClaimsValidateAll(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(1600000000, 0)
+	defer func() { TimeFunc = time.Now }()
+	TimeFunc = func() time.Time { return now }
+
+	assert.Nil(StandardClaims{}.ValidateAll())
+
+	claims := StandardClaims{
+		ExpiresAt: now.Add(-time.Minute).Unix(),
+		IssuedAt:  now.Add(time.Minute).Unix(),
+	}
+
+	// valid short circuits on the first failure
+	err := claims.Valid()
+	assert.True(ex.Is(err, ErrValidationExpired))
+	assert.False(ex.Is(err, ErrValidationIssued))
+
+	err = claims.ValidateAll()
+	assert.Len(ex.Unwrap(err), 2)
+	assert.True(ex.Is(err, ErrValidationExpired))
+	assert.True(ex.Is(err, ErrValidationIssued))
+	assert.False(ex.Is(err, ErrValidationNotBefore))
+
+	// a single failure is returned as is
+	err = StandardClaims{NotBefore: now.Add(time.Minute).Unix()}.ValidateAll()
+	assert.Len(ex.Unwrap(err), 1)
+	assert.True(ex.Is(err, ErrValidationNotBefore))
+}
+
+func TestMapClaimsValidateAll(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(1600000000, 0)
+	defer func() { TimeFunc = time.Now }()
+	TimeFunc = func() time.Time { return now }
+
+	claims := MapClaims{
+		"exp": float64(now.Add(-time.Minute).Unix()),
+		"iat": float64(now.Add(time.Minute).Unix()),
+		"nbf": float64(now.Add(time.Minute).Unix()),
+	}
+	assert.True(ex.Is(claims.Valid(), ErrValidationExpired))
+	err := claims.ValidateAll()
+	assert.Len(ex.Unwrap(err), 3)
+	assert.True(ex.Is(err, ErrValidationExpired))
+	assert.True(ex.Is(err, ErrValidationIssued))
+	assert.True(ex.Is(err, ErrValidationNotBefore))
+}
+
+func TestVerifyIssuedAtWithLeeway(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(1600000000, 0)
+	issuedAt := now.Add(2 * time.Second)
+
+	standard := StandardClaims{IssuedAt: issuedAt.Unix()}
+	assert.False(standard.VerifyIssuedAt(now.Unix(), true))
+	assert.False(standard.VerifyIssuedAtWithLeeway(now.Unix(), 0, true))
+	assert.True(standard.VerifyIssuedAtWithLeeway(now.Unix(), 5*time.Second, true))
+
+	mapClaims := MapClaims{"iat": float64(issuedAt.Unix())}
+	assert.False(mapClaims.VerifyIssuedAt(now.Unix(), true))
+	assert.False(mapClaims.VerifyIssuedAtWithLeeway(now.Unix(), 0, true))
+	assert.True(mapClaims.VerifyIssuedAtWithLeeway(now.Unix(), 5*time.Second, true))
+
+	registered := RegisteredClaims{IssuedAt: NewNumericDate(issuedAt)}
+	assert.False(registered.VerifyIssuedAt(now, true))
+	assert.False(registered.VerifyIssuedAtWithLeeway(now, 0, true))
+	assert.True(registered.VerifyIssuedAtWithLeeway(now, 5*time.Second, true))
+}
+
+func TestStandardClaimsAudience(t *testing.T) {
+	assert := assert.New(t)
+
+	var claims StandardClaims
+	assert.Nil(json.Unmarshal([]byte(`{"aud":["x","y"],"sub":"user"}`), &claims))
+	assert.Equal(Audience{"x", "y"}, claims.Audience)
+	assert.Equal("user", claims.Subject)
+	assert.True(claims.VerifyAudience("y", true))
+	assert.False(claims.VerifyAudience("z", true))
+
+	claims = StandardClaims{}
+	assert.Nil(json.Unmarshal([]byte(`{"aud":"x"}`), &claims))
+	assert.Equal(Audience{"x"}, claims.Audience)
+	assert.True(claims.VerifyAudience("x", true))
+
+	claims = StandardClaims{}
+	assert.Nil(json.Unmarshal([]byte(`{"sub":"user"}`), &claims))
+	assert.Empty(claims.Audience)
+	assert.True(claims.VerifyAudience("x", false))
+	assert.False(claims.VerifyAudience("x", true))
+
+	contents, err := json.Marshal(StandardClaims{Audience: Audience{"x"}})
+	assert.Nil(err)
+	assert.Equal(`{"aud":"x"}`, string(contents))
+	contents, err = json.Marshal(StandardClaims{Subject: "user"})
+	assert.Nil(err)
+	assert.Equal(`{"sub":"user"}`, string(contents))
+}
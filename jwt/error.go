@@ -21,7 +21,12 @@ var (
 
 	ErrValidationSignature ex.Class = "signature is invalid"
 
+	ErrInvalidNumericDate ex.Class = "numeric date is invalid"
+	ErrInvalidAudience    ex.Class = "audience is invalid"
+
 	ErrKeyfuncUnset         ex.Class = "keyfunc is unset"
+	ErrKeyIDMissing         ex.Class = "token header kid is missing"
+	ErrKeyIDNotFound        ex.Class = "token header kid is not in the key set"
 	ErrInvalidKey           ex.Class = "key is invalid"
 	ErrInvalidKeyType       ex.Class = "key is of invalid type"
 	ErrInvalidSigningMethod ex.Class = "invalid signing method"
@@ -29,6 +34,8 @@ var (
 
 	ErrHMACSignatureInvalid ex.Class = "hmac signature is invalid"
 
+	ErrNoneSignatureInvalid ex.Class = "'none' signature type is not allowed"
+
 	ErrECDSAVerification ex.Class = "crypto/ecdsa: verification error"
 
 	ErrKeyMustBePEMEncoded ex.Class = "invalid key: key must be pem encoded pkcs1 or pkcs8 private key"
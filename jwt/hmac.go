@@ -10,6 +10,8 @@ package jwt
 import (
 	"crypto"
 	"crypto/hmac"
+	"encoding/pem"
+	"strings"
 
 	"github.com/blend/go-sdk/ex"
 )
@@ -33,6 +35,11 @@ func (m *SigningMethodHMAC) Verify(signingString, signature string, key interfac
 	if !ok {
 		return ex.New(ErrInvalidKeyType)
 	}
+	// Reject asymmetric public keys used as an hmac secret, i.e.
+	// a token with an `HS*` alg verified against an RSA or ECDSA public key.
+	if isPEMPublicKey(keyBytes) {
+		return ex.New(ErrInvalidKeyType, ex.OptMessage("public keys cannot be used as hmac secrets"))
+	}
 
 	// Decode signature, for comparison
 	sig, err := DecodeSegment(signature)
@@ -60,6 +67,20 @@ func (m *SigningMethodHMAC) Verify(signingString, signature string, key interfac
 	return nil
 }
 
+// isPEMPublicKey returns if the key contains a pem encoded public key or certificate.
+func isPEMPublicKey(key []byte) bool {
+	for rest := key; len(rest) > 0; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			return false
+		}
+		if strings.Contains(block.Type, "PUBLIC KEY") || strings.Contains(block.Type, "CERTIFICATE") {
+			return true
+		}
+	}
+	return false
+}
+
 // Sign implements the Sign method from SigningMethod for this signing method.
 // Key must be []byte
 func (m *SigningMethodHMAC) Sign(signingString string, key interface{}) (string, error) {
@@ -7,9 +7,49 @@ Use of this source code is governed by a MIT license that can be found in the LI
 
 package jwt
 
+import "github.com/blend/go-sdk/ex"
+
 // Keyfunc should return the key used in verification based on the raw token passed to it.
 type Keyfunc func(*Token) (interface{}, error)
 
+// KeyfuncFromJWKS returns a key func that selects the key to verify a token with
+// from a set of rsa JWKs by the token's `kid` header.
+//
+// Tokens without a `kid` header, or with a `kid` that isn't in the set, are rejected,
+// as are tokens whose `alg` header doesn't match the `alg` of a JWK that sets one.
+// If multiple JWKs have the same `kid`, the first one is used.
+//
+// Every rejection is an `ErrValidation` error, with the specific error (e.g. `ErrKeyIDNotFound`) as its inner error.
+func KeyfuncFromJWKS(jwks ...JWK) Keyfunc {
+	keys := make(map[string]JWK, len(jwks))
+	for _, jwk := range jwks {
+		if _, ok := keys[jwk.KID]; !ok {
+			keys[jwk.KID] = jwk
+		}
+	}
+	return func(token *Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, ex.New(ErrValidation, ex.OptInner(ErrKeyIDMissing))
+		}
+		jwk, ok := keys[kid]
+		if !ok {
+			return nil, ex.New(ErrValidation, ex.OptInner(ex.New(ErrKeyIDNotFound, ex.OptMessagef("kid: %s", kid))))
+		}
+		if alg, _ := token.Header["alg"].(string); jwk.ALG != "" && jwk.ALG != alg {
+			return nil, ex.New(ErrValidation, ex.OptInner(ex.New(ErrInvalidSigningMethod, ex.OptMessagef("kid: %s, alg: %s", kid, alg))))
+		}
+		if jwk.KTY != KTYRSA {
+			return nil, ex.New(ErrValidation, ex.OptInner(ex.New(ErrInvalidKeyType, ex.OptMessagef("kid: %s, kty: %s", kid, jwk.KTY))))
+		}
+		key, err := jwk.RSAPublicKey()
+		if err != nil {
+			return nil, ex.New(ErrValidation, ex.OptInner(ex.New(ErrInvalidKey, ex.OptMessagef("kid: %s", kid), ex.OptInner(err))))
+		}
+		return key, nil
+	}
+}
+
 // KeyfuncStatic returns a static key func.
 func KeyfuncStatic(key []byte) Keyfunc {
 	return func(_ *Token) (interface{}, error) {
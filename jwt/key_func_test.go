@@ -0,0 +1,96 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func signedWithKID(assert *assert.Assertions, method SigningMethod, kid string, key interface{}) string {
+	token := New(method)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.Nil(err)
+	return signed
+}
+
+func TestKeyfuncFromJWKS(t *testing.T) {
+	assert := assert.New(t)
+
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(err)
+
+	firstJWK := RSAPublicKeyToJWK(&first.PublicKey)
+	firstJWK.KID = "first"
+	firstJWK.ALG = SigningMethodRS256.Alg()
+	secondJWK := RSAPublicKeyToJWK(&second.PublicKey)
+	secondJWK.KID = "second"
+	keyfunc := KeyfuncFromJWKS(firstJWK, secondJWK)
+
+	// the key func gets the parsed header.
+	var header map[string]interface{}
+	token, err := Parse(signedWithKID(assert, SigningMethodRS256, "first", first), func(token *Token) (interface{}, error) {
+		header = token.Header
+		return keyfunc(token)
+	})
+	assert.Nil(err)
+	assert.True(token.Valid)
+	assert.Equal("first", header["kid"])
+	assert.Equal("RS256", header["alg"])
+
+	token, err = Parse(signedWithKID(assert, SigningMethodRS512, "second", second), keyfunc)
+	assert.Nil(err)
+	assert.True(token.Valid)
+
+	// the kid selects the key.
+	_, err = Parse(signedWithKID(assert, SigningMethodRS256, "second", first), keyfunc)
+	assert.True(IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), ErrValidationSignature))
+
+	_, err = Parse(signedWithKID(assert, SigningMethodRS256, "", first), keyfunc)
+	assert.True(IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), ErrKeyIDMissing))
+
+	_, err = Parse(signedWithKID(assert, SigningMethodRS256, "third", first), keyfunc)
+	assert.True(IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), ErrKeyIDNotFound))
+
+	// the jwk alg pins the signing method.
+	_, err = Parse(signedWithKID(assert, SigningMethodRS512, "first", first), keyfunc)
+	assert.True(IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), ErrInvalidSigningMethod))
+}
+
+func TestKeyfuncFromJWKSInvalidKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	keyfunc := KeyfuncFromJWKS(
+		JWK{KTY: "EC", KID: "ec"},
+		JWK{KTY: KTYRSA, KID: "bad", N: "!!!", E: "AQAB"},
+	)
+
+	_, err := keyfunc(&Token{Header: map[string]interface{}{"kid": "ec", "alg": "ES256"}})
+	assert.True(IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), ErrInvalidKeyType))
+	_, err = keyfunc(&Token{Header: map[string]interface{}{"kid": "bad", "alg": "RS256"}})
+	assert.True(IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), ErrInvalidKey))
+	_, err = keyfunc(&Token{Header: map[string]interface{}{"kid": 1234, "alg": "RS256"}})
+	assert.True(ex.Is(ex.ErrInner(err), ErrKeyIDMissing))
+	_, err = KeyfuncFromJWKS()(&Token{Header: map[string]interface{}{"kid": "ec"}})
+	assert.True(ex.Is(ex.ErrInner(err), ErrKeyIDNotFound))
+}
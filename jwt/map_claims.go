@@ -9,6 +9,7 @@ package jwt
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/blend/go-sdk/ex"
 )
@@ -18,8 +19,22 @@ import (
 type MapClaims map[string]interface{}
 
 // VerifyAudience compares the aud claim against cmp.
+// The aud claim may be a single string or an array of strings, in which case
+// this method will return true if any of the values match.
 // If required is false, this method will return true if the value matches or is unset
 func (m MapClaims) VerifyAudience(cmp string, req bool) bool {
+	switch aud := m["aud"].(type) {
+	case []string:
+		return verifyAudiences(aud, cmp, req)
+	case []interface{}:
+		values := make([]string, 0, len(aud))
+		for _, value := range aud {
+			if valueString, ok := value.(string); ok {
+				values = append(values, valueString)
+			}
+		}
+		return verifyAudiences(values, cmp, req)
+	}
 	aud, _ := m["aud"].(string)
 	return verifyAud(aud, cmp, req)
 }
@@ -43,15 +58,22 @@ func (m MapClaims) VerifyExpiresAt(cmp int64, req bool) bool {
 // VerifyIssuedAt compares the iat claim against cmp.
 // If required is false, this method will return true if the value matches or is unset
 func (m MapClaims) VerifyIssuedAt(cmp int64, req bool) bool {
+	return m.VerifyIssuedAtWithLeeway(cmp, 0, req)
+}
+
+// VerifyIssuedAtWithLeeway compares the iat claim against cmp, tolerating
+// tokens issued up to the given leeway in the future.
+// If required is false, this method will return true if the value matches or is unset
+func (m MapClaims) VerifyIssuedAtWithLeeway(cmp int64, leeway time.Duration, req bool) bool {
 	switch iat := m["iat"].(type) {
 	case float64:
-		return verifyIat(int64(iat), cmp, req)
+		return verifyIat(int64(iat), cmp, leeway, req)
 	case json.Number:
 		v, err := iat.Int64()
 		if err != nil {
 			return false
 		}
-		return verifyIat(v, cmp, req)
+		return verifyIat(v, cmp, leeway, req)
 	}
 	return true
 }
@@ -80,23 +102,46 @@ func (m MapClaims) VerifyNotBefore(cmp int64, req bool) bool {
 }
 
 // Valid validates time based claims "exp, iat, nbf".
-// There is no accounting for clock skew.
+// There is no accounting for clock skew, use ValidWithLeeway for that.
 // As well, if any of the above claims are not in the token, it will still
 // be considered a valid claim.
 func (m MapClaims) Valid() error {
-	now := TimeFunc().Unix()
+	return m.ValidWithLeeway(0)
+}
 
-	if !m.VerifyExpiresAt(now, false) {
-		return ex.New(ErrValidationExpired)
+// ValidWithLeeway validates time based claims "exp, iat, nbf", tolerating
+// clock skew of up to the given leeway.
+// As well, if any of the above claims are not in the token, it will still
+// be considered a valid claim.
+func (m MapClaims) ValidWithLeeway(leeway time.Duration) error {
+	if errs := m.validationErrors(leeway); len(errs) > 0 {
+		return errs[0]
 	}
+	return nil
+}
+
+// ValidateAll validates time based claims "exp, iat, nbf" like Valid does, but
+// instead of returning the first failure it returns every failure.
+//
+// If there is more than one failure, the returned error is an `ex.Multi`, and
+// each failure can be matched with `ex.Is`, e.g. against ErrValidationExpired.
+func (m MapClaims) ValidateAll() error {
+	return ex.Append(nil, m.validationErrors(0)...)
+}
 
-	if !m.VerifyIssuedAt(now, false) {
-		return ex.New(ErrValidationIssued)
+func (m MapClaims) validationErrors(leeway time.Duration) (errs []error) {
+	now := TimeFunc()
+
+	if !m.VerifyExpiresAt(now.Add(-leeway).Unix(), false) {
+		errs = append(errs, ex.New(ErrValidationExpired))
 	}
 
-	if !m.VerifyNotBefore(now, false) {
-		return ex.New(ErrValidationNotBefore)
+	if !m.VerifyIssuedAtWithLeeway(now.Unix(), leeway, false) {
+		errs = append(errs, ex.New(ErrValidationIssued))
 	}
 
-	return nil
+	if !m.VerifyNotBefore(now.Add(leeway).Unix(), false) {
+		errs = append(errs, ex.New(ErrValidationNotBefore))
+	}
+	return
 }
@@ -0,0 +1,49 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import "github.com/blend/go-sdk/ex"
+
+// unsafeNoneMagicConstant is the unexported type of `UnsafeAllowNoneSignatureType`
+// so that it can't be provided by accident, e.g. by decoding a key from config.
+type unsafeNoneMagicConstant string
+
+// UnsafeAllowNoneSignatureType is the only key accepted by `SigningMethodNone`.
+//
+// Return it from a `Keyfunc` to explicitly allow unsigned (`alg: none`) tokens;
+// any other key is rejected.
+const UnsafeAllowNoneSignatureType unsafeNoneMagicConstant = "none signing method allowed"
+
+// SigningMethodNoneType implements the `none` signing method, i.e. unsigned tokens.
+//
+// It is rejected unless the key is `UnsafeAllowNoneSignatureType`.
+type SigningMethodNoneType struct{}
+
+// Alg returns the name of the signing method.
+func (m *SigningMethodNoneType) Alg() string {
+	return SigningMethodNameNone
+}
+
+// Verify only accepts an empty signature with the `UnsafeAllowNoneSignatureType` key.
+func (m *SigningMethodNoneType) Verify(signingString, signature string, key interface{}) error {
+	if key != UnsafeAllowNoneSignatureType {
+		return ex.New(ErrNoneSignatureInvalid)
+	}
+	if signature != "" {
+		return ex.New(ErrNoneSignatureInvalid, ex.OptMessage("'none' signing method with non-empty signature"))
+	}
+	return nil
+}
+
+// Sign returns an empty signature, and only accepts the `UnsafeAllowNoneSignatureType` key.
+func (m *SigningMethodNoneType) Sign(signingString string, key interface{}) (string, error) {
+	if key != UnsafeAllowNoneSignatureType {
+		return "", ex.New(ErrNoneSignatureInvalid)
+	}
+	return "", nil
+}
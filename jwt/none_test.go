@@ -0,0 +1,79 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/jwt"
+)
+
+func TestParseNone(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"foo": "bar"}).SignedString([]byte(HMACTestKey))
+	assert.True(ex.Is(err, jwt.ErrNoneSignatureInvalid))
+
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"foo": "bar"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.Nil(err)
+
+	// rejected by default, even if the key func returns a real key
+	token, err := jwt.Parse(tokenString, func(_ *jwt.Token) (interface{}, error) { return []byte(HMACTestKey), nil })
+	assert.True(jwt.IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), jwt.ErrValidationSignature))
+	assert.False(token.Valid)
+
+	token, err = jwt.Parse(tokenString, func(_ *jwt.Token) (interface{}, error) { return nil, nil })
+	assert.True(jwt.IsValidation(err))
+	assert.False(token.Valid)
+
+	// allowed explicitly
+	token, err = jwt.Parse(tokenString, func(_ *jwt.Token) (interface{}, error) { return jwt.UnsafeAllowNoneSignatureType, nil })
+	assert.Nil(err)
+	assert.True(token.Valid)
+	assert.Equal("bar", token.Claims.(jwt.MapClaims)["foo"])
+
+	// a none token with a signature is still rejected
+	_, err = jwt.Parse(tokenString+"c2lnbmF0dXJl", func(_ *jwt.Token) (interface{}, error) { return jwt.UnsafeAllowNoneSignatureType, nil })
+	assert.True(jwt.IsValidation(err))
+}
+
+func TestParseVerifiesSignatureBeforeClaims(t *testing.T) {
+	assert := assert.New(t)
+
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHMAC256, jwt.MapClaims{
+		"exp": float64(time.Now().Add(-time.Hour).Unix()),
+	}).SignedString([]byte("not the key"))
+	assert.Nil(err)
+
+	_, err = jwt.Parse(tokenString, jwt.KeyfuncStatic([]byte(HMACTestKey)))
+	assert.True(jwt.IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), jwt.ErrValidationSignature), "forged tokens should fail on the signature, not the claims")
+}
+
+func TestParseAlgorithmConfusion(t *testing.T) {
+	assert := assert.New(t)
+
+	// a token signed with HS256 using the RSA public key bytes as the hmac secret.
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHMAC256, jwt.MapClaims{"admin": true}).SignedString(SampleKeyPublic)
+	assert.Nil(err)
+
+	// the key func returns the parsed RSA public key
+	token, err := jwt.Parse(tokenString, func(_ *jwt.Token) (interface{}, error) { return MustLoadRSAPublicKey(SampleKeyPublic), nil })
+	assert.True(jwt.IsValidation(err))
+	assert.False(token.Valid)
+
+	// the key func returns the pem encoded RSA public key
+	token, err = jwt.Parse(tokenString, jwt.KeyfuncStatic(SampleKeyPublic))
+	assert.True(jwt.IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(ex.ErrInner(err)), jwt.ErrInvalidKeyType))
+	assert.False(token.Valid)
+}
@@ -0,0 +1,57 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// NewNumericDate returns a new numeric date for a given time.
+//
+// The time is truncated to second precision, as it would be when serialized.
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{Time: t.Truncate(time.Second)}
+}
+
+// NumericDate is a JSON numeric date value, as referenced at
+// https://tools.ietf.org/html/rfc7519#section-2
+//
+// It is the number of seconds from 1970-01-01T00:00:00Z UTC until the specified
+// UTC date/time, ignoring leap seconds, and may contain fractional seconds.
+type NumericDate struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// It serializes the date as a whole number of seconds since the epoch.
+func (date NumericDate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(date.Unix(), 10)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts both whole and fractional numbers of seconds since the epoch.
+func (date *NumericDate) UnmarshalJSON(contents []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(contents, &number); err != nil {
+		return ex.New(ErrInvalidNumericDate, ex.OptInner(err))
+	}
+	value, err := number.Float64()
+	if err != nil {
+		return ex.New(ErrInvalidNumericDate, ex.OptInner(err))
+	}
+	seconds, fraction := math.Modf(value)
+	date.Time = time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestNumericDateJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	date := NewNumericDate(time.Unix(1600000000, int64(500*time.Millisecond)))
+	assert.Equal(time.Unix(1600000000, 0).Unix(), date.Unix())
+	assert.Zero(date.Nanosecond())
+
+	contents, err := json.Marshal(date)
+	assert.Nil(err)
+	assert.Equal("1600000000", string(contents))
+
+	var verify NumericDate
+	assert.Nil(json.Unmarshal([]byte("1600000000"), &verify))
+	assert.True(verify.Equal(time.Unix(1600000000, 0)))
+
+	assert.Nil(json.Unmarshal([]byte("1600000000.25"), &verify))
+	assert.True(verify.Equal(time.Unix(1600000000, int64(250*time.Millisecond))))
+
+	err = json.Unmarshal([]byte(`"yesterday"`), &verify)
+	assert.True(ex.Is(err, ErrInvalidNumericDate))
+}
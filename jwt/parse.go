@@ -14,6 +14,10 @@ import (
 
 // Parse validates and returns a token from a given string.
 // KeyFunc will receive the parsed token and should return the key for validating.
+//
+// The signature is verified with the signing method named by the `alg` header before the
+// claims are validated. Unsigned (`alg: none`) tokens are rejected unless the key func
+// returns `UnsafeAllowNoneSignatureType`.
 func Parse(tokenString string, keyFunc Keyfunc) (*Token, error) {
 	return new(Parser).Parse(tokenString, keyFunc)
 }
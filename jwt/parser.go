@@ -15,6 +15,29 @@ import (
 	"github.com/blend/go-sdk/ex"
 )
 
+// NewParser returns a new parser with a given set of options.
+func NewParser(opts ...ParserOption) *Parser {
+	p := new(Parser)
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ParserOption mutates a parser.
+type ParserOption func(*Parser)
+
+// WithValidMethods pins the `alg` header values the parser accepts, e.g. `[]string{"RS256"}`.
+//
+// Tokens with any other `alg` are rejected before the key func is called, which guards
+// against algorithm confusion (e.g. an `HS256` token verified with an RSA public key as the secret).
+// Passing an empty set rejects every token.
+func WithValidMethods(methods []string) ParserOption {
+	return func(p *Parser) {
+		p.ValidMethods = append([]string{}, methods...)
+	}
+}
+
 // Parser is a parser for tokens.
 type Parser struct {
 	ValidMethods         []string // If populated, only these methods will be considered valid
@@ -34,7 +57,7 @@ func (p *Parser) ParseWithClaims(tokenString string, claims Claims, keyFunc Keyf
 		return token, err
 	}
 
-	// Verify signing method is in the required set
+	// Verify signing method is in the required set before consulting any key material
 	if p.ValidMethods != nil {
 		var signingMethodValid = false
 		var alg = token.Method.Alg()
@@ -60,6 +83,12 @@ func (p *Parser) ParseWithClaims(tokenString string, claims Claims, keyFunc Keyf
 		return token, err
 	}
 
+	// Verify the signature before trusting any of the claims
+	token.Signature = parts[2]
+	if err = token.Method.Verify(strings.Join(parts[0:2], "."), token.Signature, key); err != nil {
+		return token, ex.New(ErrValidation, ex.OptInner(ex.New(ErrValidationSignature, ex.OptInner(err))))
+	}
+
 	// Validate Claims
 	if !p.SkipClaimsValidation {
 		if err := token.Claims.Valid(); err != nil {
@@ -68,12 +97,6 @@ func (p *Parser) ParseWithClaims(tokenString string, claims Claims, keyFunc Keyf
 		}
 	}
 
-	// Perform validation
-	token.Signature = parts[2]
-	if err = token.Method.Verify(strings.Join(parts[0:2], "."), token.Signature, key); err != nil {
-		return token, ex.New(ErrValidation, ex.OptInner(ex.New(ErrValidationSignature, ex.OptInner(err))))
-	}
-
 	token.Valid = true
 	return token, nil
 }
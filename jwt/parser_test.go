@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/blend/go-sdk/assert"
 	"github.com/blend/go-sdk/ex"
 	"github.com/blend/go-sdk/jwt"
 )
@@ -313,6 +314,45 @@ func TestParser_ParseUnverified(t *testing.T) {
 	}
 }
 
+func TestParserWithValidMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	// a token signed with HS256 using the RSA public key bytes as the hmac secret.
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHMAC256, jwt.MapClaims{"admin": true}).SignedString(SampleKeyPublic)
+	assert.Nil(err)
+
+	var keyFuncCalls int
+	keyFunc := func(_ *jwt.Token) (interface{}, error) {
+		keyFuncCalls++
+		return SampleKeyPublic, nil
+	}
+
+	token, err := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodNameRS256})).Parse(tokenString, keyFunc)
+	assert.True(jwt.IsValidation(err))
+	assert.True(ex.Is(ex.ErrInner(err), jwt.ErrInvalidSigningMethod))
+	assert.False(token.Valid)
+	assert.Zero(keyFuncCalls, "the key func should not be consulted for a disallowed alg")
+
+	// an empty allowlist fails closed
+	_, err = jwt.NewParser(jwt.WithValidMethods(nil)).Parse(tokenString, keyFunc)
+	assert.True(ex.Is(ex.ErrInner(err), jwt.ErrInvalidSigningMethod))
+	assert.Zero(keyFuncCalls)
+
+	// a pinned RS256 token still verifies
+	rsaToken := MakeSampleToken(jwt.MapClaims{"foo": "bar"}, MustLoadRSAPrivateKey(SampleKey))
+	token, err = jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodNameRS256})).Parse(rsaToken, defaultKeyFunc)
+	assert.Nil(err)
+	assert.True(token.Valid)
+
+	// the allowlist is copied
+	methods := []string{jwt.SigningMethodNameRS256}
+	parser := jwt.NewParser(jwt.WithValidMethods(methods))
+	methods[0] = jwt.SigningMethodNameHMAC256
+	_, err = parser.Parse(tokenString, keyFunc)
+	assert.True(ex.Is(ex.ErrInner(err), jwt.ErrInvalidSigningMethod))
+	assert.Zero(keyFuncCalls)
+}
+
 // Helper method for benchmarking various methods
 func benchmarkSigning(b *testing.B, method jwt.SigningMethod, key interface{}) {
 	t := jwt.New(method)
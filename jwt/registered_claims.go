@@ -0,0 +1,126 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// RegisteredClaims are a structured version of the registered claims, as referenced at
+// https://tools.ietf.org/html/rfc7519#section-4.1
+//
+// Unlike StandardClaims, the time based claims are represented as NumericDate values,
+// the audience may have multiple values, and this type is preferred to StandardClaims.
+type RegisteredClaims struct {
+	ID        string       `json:"jti,omitempty"`
+	Audience  Audience     `json:"aud,omitempty"`
+	ExpiresAt *NumericDate `json:"exp,omitempty"`
+	IssuedAt  *NumericDate `json:"iat,omitempty"`
+	Issuer    string       `json:"iss,omitempty"`
+	NotBefore *NumericDate `json:"nbf,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+}
+
+// Valid asserts time based claims "exp, iat, nbf".
+// There is no accounting for clock skew, use ValidWithLeeway for that.
+// As well, if any of the above claims are not in the token, it will still
+// be considered a valid claim.
+func (c RegisteredClaims) Valid() error {
+	return c.ValidWithLeeway(0)
+}
+
+// ValidWithLeeway asserts time based claims "exp, iat, nbf", tolerating
+// clock skew of up to the given leeway.
+// As well, if any of the above claims are not in the token, it will still
+// be considered a valid claim.
+func (c RegisteredClaims) ValidWithLeeway(leeway time.Duration) error {
+	if errs := c.validationErrors(leeway); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateAll asserts time based claims "exp, iat, nbf" like Valid does, but
+// instead of returning the first failure it returns every failure.
+//
+// If there is more than one failure, the returned error is an `ex.Multi`, and
+// each failure can be matched with `ex.Is`, e.g. against ErrValidationExpired.
+func (c RegisteredClaims) ValidateAll() error {
+	return ex.Append(nil, c.validationErrors(0)...)
+}
+
+func (c RegisteredClaims) validationErrors(leeway time.Duration) (errs []error) {
+	now := TimeFunc()
+
+	if !c.VerifyExpiresAt(now.Add(-leeway), false) {
+		delta := now.Sub(c.ExpiresAt.Time)
+		errs = append(errs, ex.New(ErrValidationExpired, ex.OptMessagef("token is expired by %v", delta)))
+	}
+
+	if !c.VerifyIssuedAtWithLeeway(now, leeway, false) {
+		errs = append(errs, ex.New(ErrValidationIssued,
+			ex.OptMessagef("issued at: %s, now: %s",
+				c.IssuedAt.Format(time.RFC3339),
+				now.Format(time.RFC3339),
+			),
+		))
+	}
+
+	if !c.VerifyNotBefore(now.Add(leeway), false) {
+		errs = append(errs, ex.New(ErrValidationNotBefore))
+	}
+	return
+}
+
+// VerifyAudience compares the aud claim against cmp, returning true if any of the values match.
+// If required is false, this method will return true if the value matches or is unset
+func (c *RegisteredClaims) VerifyAudience(cmp string, req bool) bool {
+	return c.Audience.VerifyAudience(cmp, req)
+}
+
+// VerifyExpiresAt compares the exp claim against cmp.
+// If required is false, this method will return true if the value matches or is unset
+func (c *RegisteredClaims) VerifyExpiresAt(cmp time.Time, req bool) bool {
+	if c.ExpiresAt == nil {
+		return !req
+	}
+	return !cmp.After(c.ExpiresAt.Time)
+}
+
+// VerifyIssuedAt compares the iat claim against cmp.
+// If required is false, this method will return true if the value matches or is unset
+func (c *RegisteredClaims) VerifyIssuedAt(cmp time.Time, req bool) bool {
+	return c.VerifyIssuedAtWithLeeway(cmp, 0, req)
+}
+
+// VerifyIssuedAtWithLeeway compares the iat claim against cmp, tolerating
+// tokens issued up to the given leeway in the future.
+// If required is false, this method will return true if the value matches or is unset
+func (c *RegisteredClaims) VerifyIssuedAtWithLeeway(cmp time.Time, leeway time.Duration, req bool) bool {
+	if c.IssuedAt == nil {
+		return !req
+	}
+	return !cmp.Add(leeway).Before(c.IssuedAt.Time)
+}
+
+// VerifyIssuer compares the iss claim against cmp.
+// If required is false, this method will return true if the value matches or is unset
+func (c *RegisteredClaims) VerifyIssuer(cmp string, req bool) bool {
+	return verifyIss(c.Issuer, cmp, req)
+}
+
+// VerifyNotBefore compares the nbf claim against cmp.
+// If required is false, this method will return true if the value matches or is unset
+func (c *RegisteredClaims) VerifyNotBefore(cmp time.Time, req bool) bool {
+	if c.NotBefore == nil {
+		return !req
+	}
+	return !cmp.Before(c.NotBefore.Time)
+}
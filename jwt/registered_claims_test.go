@@ -0,0 +1,118 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestRegisteredClaimsJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	claims := RegisteredClaims{
+		Subject:   "subject",
+		ExpiresAt: NewNumericDate(time.Unix(1600000100, 0)),
+		IssuedAt:  NewNumericDate(time.Unix(1600000000, 0)),
+	}
+	contents, err := json.Marshal(claims)
+	assert.Nil(err)
+	assert.Equal(`{"exp":1600000100,"iat":1600000000,"sub":"subject"}`, string(contents))
+
+	var verify RegisteredClaims
+	assert.Nil(json.Unmarshal([]byte(`{"exp":1600000100.5,"nbf":1600000000,"sub":"subject"}`), &verify))
+	assert.Equal("subject", verify.Subject)
+	assert.True(verify.ExpiresAt.Equal(time.Unix(1600000100, int64(500*time.Millisecond))))
+	assert.True(verify.NotBefore.Equal(time.Unix(1600000000, 0)))
+	assert.Nil(verify.IssuedAt)
+}
+
+func TestRegisteredClaimsValid(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	defer func() { TimeFunc = time.Now }()
+	TimeFunc = func() time.Time { return now }
+
+	assert.Nil(RegisteredClaims{}.Valid())
+	assert.Nil(RegisteredClaims{
+		ExpiresAt: &NumericDate{now.Add(time.Minute)},
+		IssuedAt:  &NumericDate{now.Add(-time.Minute)},
+		NotBefore: &NumericDate{now},
+	}.Valid())
+
+	err := RegisteredClaims{ExpiresAt: &NumericDate{now.Add(-time.Minute)}}.Valid()
+	assert.True(ex.Is(err, ErrValidationExpired))
+
+	err = RegisteredClaims{IssuedAt: &NumericDate{now.Add(time.Minute)}}.Valid()
+	assert.True(ex.Is(err, ErrValidationIssued))
+
+	err = RegisteredClaims{NotBefore: &NumericDate{now.Add(time.Minute)}}.Valid()
+	assert.True(ex.Is(err, ErrValidationNotBefore))
+}
+
+func TestRegisteredClaimsVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	claims := RegisteredClaims{Audience: Audience{"audience"}, Issuer: "issuer"}
+	assert.True(claims.VerifyAudience("audience", true))
+	assert.False(claims.VerifyAudience("other", true))
+	assert.True(claims.VerifyIssuer("issuer", true))
+	assert.False(claims.VerifyIssuer("other", true))
+	assert.True(claims.VerifyExpiresAt(now, false))
+	assert.False(claims.VerifyExpiresAt(now, true))
+	assert.False(claims.VerifyIssuedAt(now, true))
+	assert.False(claims.VerifyNotBefore(now, true))
+}
+
+func TestRegisteredClaimsValidWithLeeway(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	defer func() { TimeFunc = time.Now }()
+	TimeFunc = func() time.Time { return now }
+
+	expired := RegisteredClaims{ExpiresAt: &NumericDate{now.Add(-3 * time.Second)}}
+	assert.True(ex.Is(expired.Valid(), ErrValidationExpired))
+	assert.True(ex.Is(expired.ValidWithLeeway(time.Second), ErrValidationExpired))
+	assert.Nil(expired.ValidWithLeeway(5 * time.Second))
+
+	issued := RegisteredClaims{IssuedAt: &NumericDate{now.Add(3 * time.Second)}}
+	assert.True(ex.Is(issued.Valid(), ErrValidationIssued))
+	assert.Nil(issued.ValidWithLeeway(5 * time.Second))
+
+	notBefore := RegisteredClaims{NotBefore: &NumericDate{now.Add(3 * time.Second)}}
+	assert.True(ex.Is(notBefore.Valid(), ErrValidationNotBefore))
+	assert.Nil(notBefore.ValidWithLeeway(5 * time.Second))
+}
+
+func TestRegisteredClaimsValidateAll(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	defer func() { TimeFunc = time.Now }()
+	TimeFunc = func() time.Time { return now }
+
+	assert.Nil(RegisteredClaims{}.ValidateAll())
+
+	claims := RegisteredClaims{
+		ExpiresAt: &NumericDate{now.Add(-time.Minute)},
+		IssuedAt:  &NumericDate{now.Add(time.Minute)},
+	}
+	assert.False(ex.Is(claims.Valid(), ErrValidationIssued))
+
+	err := claims.ValidateAll()
+	assert.Len(ex.Unwrap(err), 2)
+	assert.True(ex.Is(err, ErrValidationExpired))
+	assert.True(ex.Is(err, ErrValidationIssued))
+}
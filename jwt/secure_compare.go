@@ -0,0 +1,18 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import "crypto/subtle"
+
+// SecureCompare returns if two strings are equal, in constant time with
+// respect to their contents, for comparing sensitive claim values.
+//
+// Use this in custom claims `Valid()` implementations instead of `==`.
+func SecureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,24 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package jwt
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestSecureCompare(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(SecureCompare("", ""))
+	assert.True(SecureCompare("scope:read", "scope:read"))
+	assert.False(SecureCompare("scope:read", "scope:write"))
+	assert.False(SecureCompare("scope:read", "scope:rea"))
+	assert.False(SecureCompare("scope", ""))
+}
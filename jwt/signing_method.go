@@ -22,6 +22,8 @@ const (
 	SigningMethodNameRS256 = "RS256"
 	SigningMethodNameRS384 = "RS384"
 	SigningMethodNameRS512 = "RS512"
+
+	SigningMethodNameNone = "none"
 )
 
 // SigningMethod is a type that implements methods required to sign tokens.
@@ -44,6 +46,8 @@ var (
 	SigningMethodRS256 = &SigningMethodRSA{SigningMethodNameRS256, crypto.SHA256}
 	SigningMethodRS384 = &SigningMethodRSA{SigningMethodNameRS384, crypto.SHA384}
 	SigningMethodRS512 = &SigningMethodRSA{SigningMethodNameRS512, crypto.SHA512}
+
+	SigningMethodNone = &SigningMethodNoneType{}
 )
 
 // GetSigningMethod returns a signing method with a given name.
@@ -67,6 +71,8 @@ func GetSigningMethod(name string) SigningMethod {
 		return SigningMethodRS384
 	case SigningMethodNameRS512:
 		return SigningMethodRS512
+	case SigningMethodNameNone:
+		return SigningMethodNone
 	default:
 		return nil
 	}
@@ -0,0 +1,162 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/fileutil"
+)
+
+var (
+	_ io.WriteCloser = (*RotatingFileWriter)(nil)
+)
+
+// FileWriter returns a writer that appends to the file at a given path, rotating it
+// to `path.1`, `path.2` and so on when a write would grow it beyond `maxSize` bytes.
+//
+// At most `maxBackups` rotated files are kept; the oldest are deleted. A `maxSize` of zero
+// or less disables rotation. Use it as the logger output:
+//
+//	maxSize, _ := fileutil.ParseFileSize("100mb")
+//	output, err := logger.FileWriter("app.log", maxSize, 5)
+//	...
+//	log := logger.New(logger.OptOutput(output))
+func FileWriter(path string, maxSize int64, maxBackups int) (*RotatingFileWriter, error) {
+	fw := &RotatingFileWriter{
+		Path:       path,
+		MaxSize:    maxSize,
+		MaxBackups: maxBackups,
+	}
+	if err := fw.open(); err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+// FileWriterFromString returns a file writer with the max size parsed by `fileutil.ParseFileSize`, e.g. "100mb".
+func FileWriterFromString(path string, maxSize string, maxBackups int) (*RotatingFileWriter, error) {
+	parsed, err := fileutil.ParseFileSize(maxSize)
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	return FileWriter(path, parsed, maxBackups)
+}
+
+// RotatingFileWriter is a writer that rotates the file it writes to by size.
+//
+// It is safe to use from multiple goroutines; each write is written to a single
+// file in full, and rotation happens between writes.
+type RotatingFileWriter struct {
+	sync.Mutex
+
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+
+	file *os.File
+	size int64
+}
+
+// Write writes the given bytes to the current file, rotating it first if necessary.
+func (fw *RotatingFileWriter) Write(contents []byte) (count int, err error) {
+	fw.Lock()
+	defer fw.Unlock()
+
+	if fw.file == nil {
+		if err = fw.open(); err != nil {
+			return
+		}
+	}
+	if fw.MaxSize > 0 && fw.size > 0 && fw.size+int64(len(contents)) > fw.MaxSize {
+		if err = fw.rotate(); err != nil {
+			return
+		}
+	}
+	count, err = fw.file.Write(contents)
+	fw.size += int64(count)
+	if err != nil {
+		err = ex.New(err)
+	}
+	return
+}
+
+// Close syncs and closes the current file.
+func (fw *RotatingFileWriter) Close() error {
+	fw.Lock()
+	defer fw.Unlock()
+	return fw.close()
+}
+
+//
+// internal helpers
+//
+
+// open opens the file at the path for appending.
+func (fw *RotatingFileWriter) open() error {
+	file, err := os.OpenFile(fw.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return ex.New(err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return ex.New(err)
+	}
+	fw.file = file
+	fw.size = info.Size()
+	return nil
+}
+
+// close syncs and closes the current file (if any) so that no buffered tail is lost.
+func (fw *RotatingFileWriter) close() error {
+	if fw.file == nil {
+		return nil
+	}
+	file := fw.file
+	fw.file = nil
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return ex.New(err)
+	}
+	return ex.New(file.Close())
+}
+
+// rotate closes the current file, shifts the backups, and opens a new file.
+func (fw *RotatingFileWriter) rotate() error {
+	if err := fw.close(); err != nil {
+		return err
+	}
+	if fw.MaxBackups <= 0 {
+		if err := os.Remove(fw.Path); err != nil && !os.IsNotExist(err) {
+			return ex.New(err)
+		}
+		return fw.open()
+	}
+	if err := os.Remove(fw.backupPath(fw.MaxBackups)); err != nil && !os.IsNotExist(err) {
+		return ex.New(err)
+	}
+	for index := fw.MaxBackups - 1; index > 0; index-- {
+		if err := os.Rename(fw.backupPath(index), fw.backupPath(index+1)); err != nil && !os.IsNotExist(err) {
+			return ex.New(err)
+		}
+	}
+	if err := os.Rename(fw.Path, fw.backupPath(1)); err != nil && !os.IsNotExist(err) {
+		return ex.New(err)
+	}
+	return fw.open()
+}
+
+// backupPath returns the path of a given backup, e.g. `app.log.1`.
+func (fw *RotatingFileWriter) backupPath(index int) string {
+	return fmt.Sprintf("%s.%d", fw.Path, index)
+}
@@ -0,0 +1,159 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func TestFileWriterRotates(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	fw, err := FileWriter(path, 16, 2)
+	assert.Nil(err)
+
+	for index := 0; index < 5; index++ {
+		_, err = fmt.Fprintf(fw, "line %d ......\n", index) // 15 bytes
+		assert.Nil(err)
+	}
+	assert.Nil(fw.Close())
+
+	assert.Equal([]string{"line 4 ......"}, readLines(t, path))
+	assert.Equal([]string{"line 3 ......"}, readLines(t, path+".1"))
+	assert.Equal([]string{"line 2 ......"}, readLines(t, path+".2"))
+	_, err = os.Stat(path + ".3")
+	assert.True(os.IsNotExist(err), "backups beyond max backups should be deleted")
+
+	// writes after close reopen the file
+	_, err = fw.Write([]byte("reopened\n"))
+	assert.Nil(err)
+	assert.Nil(fw.Close())
+	assert.Equal([]string{"reopened"}, readLines(t, path))
+	assert.Equal([]string{"line 4 ......"}, readLines(t, path+".1"))
+}
+
+func TestFileWriterAppendsExisting(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	assert.Nil(os.WriteFile(path, []byte("existing\n"), 0644))
+
+	fw, err := FileWriter(path, 1024, 1)
+	assert.Nil(err)
+	_, err = fw.Write([]byte("appended\n"))
+	assert.Nil(err)
+	assert.Nil(fw.Close())
+	assert.Equal([]string{"existing", "appended"}, readLines(t, path))
+
+	// an existing file that is already full rotates on the first write
+	fw, err = FileWriter(path, 18, 1)
+	assert.Nil(err)
+	_, err = fw.Write([]byte("rotated\n"))
+	assert.Nil(err)
+	assert.Nil(fw.Close())
+	assert.Equal([]string{"rotated"}, readLines(t, path))
+	assert.Equal([]string{"existing", "appended"}, readLines(t, path+".1"))
+}
+
+func TestFileWriterNoBackups(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	fw, err := FileWriter(path, 4, 0)
+	assert.Nil(err)
+	_, err = fw.Write([]byte("one\n"))
+	assert.Nil(err)
+	_, err = fw.Write([]byte("two\n"))
+	assert.Nil(err)
+	assert.Nil(fw.Close())
+
+	assert.Equal([]string{"two"}, readLines(t, path))
+	_, err = os.Stat(path + ".1")
+	assert.True(os.IsNotExist(err))
+}
+
+func TestFileWriterFromString(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	fw, err := FileWriterFromString(path, "2kb", 3)
+	assert.Nil(err)
+	assert.Equal(2048, fw.MaxSize)
+	assert.Nil(fw.Close())
+
+	_, err = FileWriterFromString(path, "lots", 3)
+	assert.NotNil(err)
+
+	_, err = FileWriter(filepath.Join(t.TempDir(), "missing", "app.log"), 1024, 1)
+	assert.NotNil(err)
+}
+
+func TestFileWriterConcurrentEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	fw, err := FileWriter(path, 512, 100)
+	assert.Nil(err)
+
+	log := MustNew(OptOutput(fw), OptText(OptTextNoColor(), OptTextHideTimestamp()), OptAll())
+
+	const writers, events = 8, 50
+	wg := sync.WaitGroup{}
+	wg.Add(writers)
+	for writer := 0; writer < writers; writer++ {
+		go func(writer int) {
+			defer wg.Done()
+			for index := 0; index < events; index++ {
+				log.Infof("writer %d event %d", writer, index)
+			}
+		}(writer)
+	}
+	wg.Wait()
+	log.Close()
+
+	var lines []string
+	lines = append(lines, readLines(t, path)...)
+	for index := 1; index <= 100; index++ {
+		lines = append(lines, readLines(t, fmt.Sprintf("%s.%d", path, index))...)
+	}
+	assert.Len(lines, writers*events)
+	for _, line := range lines {
+		assert.True(strings.HasPrefix(line, "[info] writer "), line)
+	}
+
+	info, err := os.Stat(path + ".1")
+	assert.Nil(err)
+	assert.True(info.Size() <= 512)
+}
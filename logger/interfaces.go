@@ -46,6 +46,14 @@ type Scoper interface {
 	WithAnnotations(Annotations) Scope
 }
 
+// FieldsScoper is a type that can return a scope with structured fields.
+//
+// It is implemented by `*Logger` and `Scope`.
+type FieldsScoper interface {
+	// WithFields returns a new scope with a given set of additional structured fields.
+	WithFields(map[string]interface{}) Scope
+}
+
 // Writable is a type that can write events.
 type Writable interface {
 	Write(context.Context, Event)
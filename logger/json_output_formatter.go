@@ -99,7 +99,9 @@ func (jw JSONOutputFormatter) WriteFormat(ctx context.Context, output io.Writer,
 	} else if err := encoder.Encode(e); err != nil {
 		return err
 	}
-	_, err := io.Copy(output, buffer)
+	// write the fully encoded event in a single call so that
+	// concurrent events can't interleave on the output.
+	_, err := output.Write(buffer.Bytes())
 	return err
 }
 
@@ -10,6 +10,10 @@ package logger
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/blend/go-sdk/assert"
@@ -43,3 +47,38 @@ func TestJSONOutputFormatter(t *testing.T) {
 	assert.Nil(jf.WriteFormat(context.Background(), buf, me))
 	assert.Contains(buf.String(), "\t\"text\": \"this is a test\"\n")
 }
+
+func TestJSONOutputFormatterConcurrentWrites(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	log, err := New(
+		OptAll(),
+		OptOutput(buf),
+		OptJSON(),
+	)
+	assert.Nil(err)
+
+	const workers, events = 8, 64
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func(worker int) {
+			defer wg.Done()
+			for index := 0; index < events; index++ {
+				log.Write(context.Background(), NewMessageEvent(Info, fmt.Sprintf("worker %d event %d", worker, index)))
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(lines, workers*events)
+	for _, line := range lines {
+		var fields map[string]interface{}
+		assert.Nil(json.Unmarshal([]byte(line), &fields), line)
+		assert.Equal(Info, fields[FieldFlag])
+		assert.NotEmpty(fields[FieldTimestamp])
+		assert.HasPrefix(fmt.Sprint(fields[FieldText]), "worker ")
+	}
+}
@@ -67,6 +67,7 @@ func None(options ...Option) *Logger {
 
 // Prod returns a new logger tuned for production use.
 // It writes to os.Stderr with text output colorization disabled.
+// Pass `OptJSON()` to write structured json instead of text.
 func Prod(options ...Option) *Logger {
 	return MustNew(
 		append([]Option{
@@ -105,6 +106,7 @@ type Logger struct {
 	Output    io.Writer
 	Formatter WriteFormatter
 	Errors    chan error
+	Sampler   *Sampler
 
 	// Filters hold filters organized by flag, and then by filter name.
 	// The intent is to modify event data before it is written or given to listeners.
@@ -353,6 +355,9 @@ func (l *Logger) Write(ctx context.Context, e Event) {
 	if !l.WritableScopes.IsEnabled(GetPath(ctx)...) {
 		return
 	}
+	if l.Sampler != nil && !l.Sampler.Allowed(e.GetFlag()) {
+		return
+	}
 
 	err := l.Formatter.WriteFormat(ctx, l.Output, e)
 	if err != nil && l.Errors != nil {
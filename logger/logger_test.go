@@ -261,6 +261,32 @@ func TestLoggerListeners(t *testing.T) {
 	assert.False(log.HasListener(Error, "bar"))
 }
 
+func TestLoggerListenersPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	log := MustNew(OptAll(), OptOutput(new(bytes.Buffer)))
+	defer log.Close()
+
+	called := make(chan string, 2)
+	log.Listen(Info, "panics", NewMessageEventListener(func(_ context.Context, me MessageEvent) {
+		if me.Text == "first" {
+			panic("listener panic")
+		}
+		called <- me.Text
+	}))
+
+	log.Info("first")
+	log.Info("second")
+
+	select {
+	case text := <-called:
+		assert.Equal("second", text)
+	case <-time.After(time.Second):
+		assert.FailNow("listener should have been called after a panic")
+	}
+	assert.True(log.HasListener(Info, "panics"))
+}
+
 func TestLoggerFilters(t *testing.T) {
 	assert := assert.New(t)
 
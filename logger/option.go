@@ -83,11 +83,14 @@ func OptLabels(labels ...Labels) Option {
 }
 
 // OptJSON sets the output formatter for the logger as json.
+//
+// Each event is written as a single json object per line, including
+// the event flag and timestamp alongside the event fields.
 func OptJSON(opts ...JSONOutputFormatterOption) Option {
 	return func(l *Logger) error { l.Formatter = NewJSONOutputFormatter(opts...); return nil }
 }
 
-// OptText sets the output formatter for the logger as json.
+// OptText sets the output formatter for the logger as line oriented text.
 func OptText(opts ...TextOutputFormatterOption) Option {
 	return func(l *Logger) error { l.Formatter = NewTextOutputFormatter(opts...); return nil }
 }
@@ -97,6 +100,15 @@ func OptFormatter(formatter WriteFormatter) Option {
 	return func(l *Logger) error { l.Formatter = formatter; return nil }
 }
 
+// OptSample sets the logger to write only 1 in every `n` events of a given flag
+// after an initial burst of `n` events, resetting each second.
+//
+// Sampling only applies to written output; listeners are still triggered for every event.
+// `Fatal` and `Error` events are never sampled.
+func OptSample(n int) Option {
+	return func(l *Logger) error { l.Sampler = NewSampler(n); return nil }
+}
+
 // OptFlags sets the flags on the logger.
 func OptFlags(flags *Flags) Option {
 	return func(l *Logger) error { l.Flags = flags; return nil }
@@ -0,0 +1,78 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NewSampler returns a new sampler that writes 1 in every `n` events
+// per flag per second after an initial burst of `n` events.
+func NewSampler(n int) *Sampler {
+	return &Sampler{
+		N:   n,
+		Now: time.Now,
+	}
+}
+
+// Sampler limits how many events are written for high volume flags.
+//
+// For each flag, the first `N` events in a given second are written, and
+// after that only every `N`th event is written until the next second starts.
+// `Fatal` and `Error` events are never sampled.
+//
+// Counters are updated with atomics rather than locks, so counts that
+// straddle a second boundary are approximate.
+type Sampler struct {
+	N   int
+	Now func() time.Time
+
+	counters sync.Map
+}
+
+// sampleCounter counts events for a single flag within a second.
+type sampleCounter struct {
+	second int64
+	count  uint64
+}
+
+// Allowed returns if an event for a given flag should be written.
+func (s *Sampler) Allowed(flag string) bool {
+	if s.N <= 1 || flag == Fatal || flag == Error {
+		return true
+	}
+
+	value, ok := s.counters.Load(flag)
+	if !ok {
+		value, _ = s.counters.LoadOrStore(flag, new(sampleCounter))
+	}
+	counter := value.(*sampleCounter)
+
+	second := s.nowOrDefault().Unix()
+	if previous := atomic.LoadInt64(&counter.second); previous != second {
+		if atomic.CompareAndSwapInt64(&counter.second, previous, second) {
+			atomic.StoreUint64(&counter.count, 0)
+		}
+	}
+
+	n := uint64(s.N)
+	count := atomic.AddUint64(&counter.count, 1)
+	if count <= n {
+		return true
+	}
+	return (count-n)%n == 0
+}
+
+func (s *Sampler) nowOrDefault() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,117 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestSamplerAllowed(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 01, 02, 03, 04, 05, 0, time.UTC)
+	s := NewSampler(10)
+	s.Now = func() time.Time { return now }
+
+	var allowed int
+	for x := 0; x < 100; x++ {
+		if s.Allowed(Info) {
+			allowed++
+		}
+	}
+	// the burst of 10, and then 1 in 10 of the remaining 90.
+	assert.Equal(19, allowed)
+
+	for x := 0; x < 100; x++ {
+		assert.True(s.Allowed(Error))
+		assert.True(s.Allowed(Fatal))
+	}
+
+	// flags are counted independently.
+	assert.True(s.Allowed(Debug))
+
+	// the counters reset each second.
+	now = now.Add(time.Second)
+	for x := 0; x < 10; x++ {
+		assert.True(s.Allowed(Info))
+	}
+	assert.False(s.Allowed(Info))
+}
+
+func TestSamplerAllowedDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewSampler(1)
+	for x := 0; x < 100; x++ {
+		assert.True(s.Allowed(Info))
+	}
+}
+
+func TestLoggerSample(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(countingWriter)
+	log := MustNew(
+		OptAll(),
+		OptOutput(output),
+		OptSample(100),
+	)
+	assert.NotNil(log.Sampler)
+
+	for x := 0; x < 1000; x++ {
+		log.Write(context.Background(), NewMessageEvent(Info, "test"))
+		log.Write(context.Background(), NewMessageEvent(Error, "test"))
+	}
+	assert.True(output.Writes() < 2000)
+	assert.True(output.Writes() >= 1000)
+}
+
+func BenchmarkLoggerWrite(b *testing.B) {
+	benchmarkLoggerWrite(b)
+}
+
+func BenchmarkLoggerWriteSampled(b *testing.B) {
+	benchmarkLoggerWrite(b, OptSample(100))
+}
+
+func benchmarkLoggerWrite(b *testing.B, options ...Option) {
+	output := new(countingWriter)
+	log := MustNew(append([]Option{
+		OptAll(),
+		OptOutput(output),
+		OptText(OptTextNoColor()),
+	}, options...)...)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			log.Write(context.Background(), NewMessageEvent(Info, "this is a test"))
+		}
+	})
+	b.ReportMetric(float64(output.Writes())/float64(b.N), "writes/op")
+}
+
+// countingWriter counts the writes made to it.
+type countingWriter struct {
+	writes int64
+}
+
+func (cw *countingWriter) Write(contents []byte) (int, error) {
+	atomic.AddInt64(&cw.writes, 1)
+	return len(contents), nil
+}
+
+func (cw *countingWriter) Writes() int64 {
+	return atomic.LoadInt64(&cw.writes)
+}
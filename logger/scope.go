@@ -101,6 +101,14 @@ func (sc Scope) WithAnnotations(annotations Annotations) Scope {
 	)
 }
 
+// WithFields returns a new scope with a given additional set of structured fields.
+//
+// The fields are merged into the annotations of every event triggered by the returned
+// scope (and written by json output), and the parent scope is left unchanged.
+func (sc Scope) WithFields(fields map[string]interface{}) Scope {
+	return sc.WithAnnotations(Annotations(fields))
+}
+
 // --------------------------------------------------------------------------------
 // Trigger event handler
 // --------------------------------------------------------------------------------
@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/blend/go-sdk/assert"
@@ -63,6 +64,32 @@ func TestWithAnnotations(t *testing.T) {
 	assert.Equal("bar", sc.Annotations["foo"])
 }
 
+var (
+	_ FieldsScoper = (*Logger)(nil)
+	_ FieldsScoper = (*Scope)(nil)
+)
+
+func TestWithFields(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	log := MustNew(OptAll(), OptOutput(buf), OptJSON())
+
+	var child Log = log.WithFields(map[string]interface{}{"requestID": "abcd", "userID": 1234})
+	assert.Empty(log.Annotations)
+
+	child.Infof("first")
+	child.Info("second")
+	log.Info("parent")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(lines, 3)
+	assert.Contains(lines[0], `"requestID":"abcd"`)
+	assert.Contains(lines[0], `"userID":1234`)
+	assert.Contains(lines[1], `"requestID":"abcd"`)
+	assert.NotContains(lines[2], "requestID")
+}
+
 func TestScopeMethods(t *testing.T) {
 	assert := assert.New(t)
 
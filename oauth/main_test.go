@@ -42,7 +42,7 @@ func createJWK(pk *rsa.PrivateKey) jwt.JWK {
 func createCodeResponse(aud, keyID string, pk *rsa.PrivateKey) ([]byte, error) {
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, &GoogleClaims{
 		StandardClaims: jwt.StandardClaims{
-			Audience:  aud,
+			Audience:  jwt.Audience{aud},
 			ExpiresAt: time.Now().UTC().AddDate(0, 0, 1).Unix(),
 			IssuedAt:  time.Now().UTC().Unix(),
 			Issuer:    GoogleIssuer,
@@ -200,8 +200,8 @@ func (m *Manager) ValidateState(state State) error {
 
 // ValidateJWT returns if the jwt is valid or not.
 func (m *Manager) ValidateJWT(jwtClaims *GoogleClaims) error {
-	if jwtClaims.Audience != m.ClientID {
-		return ex.New(ErrInvalidJWTAudience, ex.OptMessagef("audience: %s", jwtClaims.Audience))
+	if !jwtClaims.VerifyAudience(m.ClientID, true) {
+		return ex.New(ErrInvalidJWTAudience, ex.OptMessagef("audience: %s", strings.Join(jwtClaims.Audience, ", ")))
 	}
 	if jwtClaims.Issuer != GoogleIssuer && jwtClaims.Issuer != GoogleIssuerAlternate {
 		return ex.New(ErrInvalidJWTIssuer, ex.OptMessagef("issuer: %s", jwtClaims.Issuer))
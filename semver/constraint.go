@@ -26,7 +26,7 @@ type Constraint struct {
 // we can add methods to it.
 type Constraints []*Constraint
 
-type constraintFunc func(v, c *Version) bool
+type constraintFunc func(v, c *Version, includePrerelease bool) bool
 
 var constraintOperators map[string]constraintFunc
 
@@ -42,6 +42,8 @@ func init() {
 		">=": constraintGreaterThanEqual,
 		"<=": constraintLessThanEqual,
 		"~>": constraintPessimistic,
+		"~":  constraintTilde,
+		"^":  constraintCaret,
 	}
 
 	ops := make([]string, 0, len(constraintOperators))
@@ -58,22 +60,47 @@ func init() {
 // NewConstraint will parse one or more constraints from the given
 // constraint string. The string must be a comma-separated list of
 // constraints.
+//
+// Supported operators are "=", "!=", ">", ">=", "<", "<=", "~>", "~" and "^".
+// The tilde operator allows patch level changes if a minor version is given
+// (e.g. "~1.4" matches ">=1.4.0, <1.5.0"), and minor level changes otherwise.
+// The caret operator allows changes that do not modify the left-most non-zero
+// segment (e.g. "^1.2.3" matches "<2.0.0" but "^0.2.3" matches "<0.3.0").
+//
+// Constraints without an operator (or with "=") may use "x", "X" or "*" as a wildcard
+// for any segment; "1.2.x" matches ">=1.2.0, <1.3.0", "1.x" matches ">=1.0.0, <2.0.0"
+// and "*" matches every version. A wildcard constraint is translated to the equivalent range.
 func NewConstraint(v string) (Constraints, error) {
 	vs := strings.Split(v, ",")
-	result := make([]*Constraint, len(vs))
-	for i, single := range vs {
+	result := make([]*Constraint, 0, len(vs))
+	for _, single := range vs {
+		wildcard, ok, err := parseWildcard(single)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, wildcard...)
+			continue
+		}
+
 		c, err := parseSingle(single)
 		if err != nil {
 			return nil, err
 		}
 
-		result[i] = c
+		result = append(result, c)
 	}
 
 	return Constraints(result), nil
 }
 
 // Check tests if a version satisfies all the constraints.
+//
+// Prerelease versions are only matched by constraints that themselves
+// include a prerelease on the same segments; for example ">= 1.0.0" does
+// not match "2.0.0-beta", but ">= 1.0.0-0" matches "1.0.0-beta" (and still
+// does not match "1.1.0-beta"). Use CheckPrerelease to match prereleases
+// purely by precedence.
 func (cs Constraints) Check(v *Version) bool {
 	for _, c := range cs {
 		if !c.Check(v) {
@@ -84,6 +111,21 @@ func (cs Constraints) Check(v *Version) bool {
 	return true
 }
 
+// CheckPrerelease tests if a version satisfies all the constraints,
+// comparing prerelease versions by precedence alone.
+//
+// For example ">= 1.0.0" matches "2.0.0-beta" with CheckPrerelease,
+// but not with Check.
+func (cs Constraints) CheckPrerelease(v *Version) bool {
+	for _, c := range cs {
+		if !c.CheckPrerelease(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Returns the string format of the constraints
 func (cs Constraints) String() string {
 	csStr := make([]string, len(cs))
@@ -96,7 +138,13 @@ func (cs Constraints) String() string {
 
 // Check tests if a constraint is validated by the given version.
 func (c *Constraint) Check(v *Version) bool {
-	return c.f(v, c.check)
+	return c.f(v, c.check, false)
+}
+
+// CheckPrerelease tests if a constraint is validated by the given version,
+// comparing prerelease versions by precedence alone.
+func (c *Constraint) CheckPrerelease(v *Version) bool {
+	return c.f(v, c.check, true)
 }
 
 // String returns the original string.
@@ -112,7 +160,7 @@ func parseSingle(v string) (*Constraint, error) {
 
 	check, err := NewVersion(matches[2])
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("malformed constraint: %s; %v", v, err)
 	}
 
 	return &Constraint{
@@ -122,7 +170,10 @@ func parseSingle(v string) (*Constraint, error) {
 	}, nil
 }
 
-func prereleaseCheck(v, c *Version) bool {
+func prereleaseCheck(v, c *Version, includePrerelease bool) bool {
+	if includePrerelease {
+		return true
+	}
 	switch vPre, cPre := v.Prerelease() != "", c.Prerelease() != ""; {
 	case cPre && vPre:
 		// A constraint with a pre-release can only match a pre-release version
@@ -146,33 +197,33 @@ func prereleaseCheck(v, c *Version) bool {
 // Constraint functions
 //-------------------------------------------------------------------
 
-func constraintEqual(v, c *Version) bool {
+func constraintEqual(v, c *Version, _ bool) bool {
 	return v.Equal(c)
 }
 
-func constraintNotEqual(v, c *Version) bool {
+func constraintNotEqual(v, c *Version, _ bool) bool {
 	return !v.Equal(c)
 }
 
-func constraintGreaterThan(v, c *Version) bool {
-	return prereleaseCheck(v, c) && v.Compare(c) == 1
+func constraintGreaterThan(v, c *Version, includePrerelease bool) bool {
+	return prereleaseCheck(v, c, includePrerelease) && v.Compare(c) == 1
 }
 
-func constraintLessThan(v, c *Version) bool {
-	return prereleaseCheck(v, c) && v.Compare(c) == -1
+func constraintLessThan(v, c *Version, includePrerelease bool) bool {
+	return prereleaseCheck(v, c, includePrerelease) && v.Compare(c) == -1
 }
 
-func constraintGreaterThanEqual(v, c *Version) bool {
-	return prereleaseCheck(v, c) && v.Compare(c) >= 0
+func constraintGreaterThanEqual(v, c *Version, includePrerelease bool) bool {
+	return prereleaseCheck(v, c, includePrerelease) && v.Compare(c) >= 0
 }
 
-func constraintLessThanEqual(v, c *Version) bool {
-	return prereleaseCheck(v, c) && v.Compare(c) <= 0
+func constraintLessThanEqual(v, c *Version, includePrerelease bool) bool {
+	return prereleaseCheck(v, c, includePrerelease) && v.Compare(c) <= 0
 }
 
-func constraintPessimistic(v, c *Version) bool {
+func constraintPessimistic(v, c *Version, includePrerelease bool) bool {
 	// Using a pessimistic constraint with a pre-release, restricts versions to pre-releases
-	if !prereleaseCheck(v, c) || (c.Prerelease() != "" && v.Prerelease() == "") {
+	if !prereleaseCheck(v, c, includePrerelease) || (c.Prerelease() != "" && v.Prerelease() == "") {
 		return false
 	}
 
@@ -205,3 +256,33 @@ func constraintPessimistic(v, c *Version) bool {
 	// be valid against the constraint
 	return c.segments[cs-1] <= v.segments[cs-1]
 }
+
+func constraintTilde(v, c *Version, includePrerelease bool) bool {
+	if !prereleaseCheck(v, c, includePrerelease) || v.LessThan(c) {
+		return false
+	}
+
+	// "~1" allows minor level changes, "~1.2" and "~1.2.3" allow
+	// patch level changes.
+	if c.si < 2 {
+		return v.Major() == c.Major()
+	}
+	return v.Major() == c.Major() && v.Minor() == c.Minor()
+}
+
+func constraintCaret(v, c *Version, includePrerelease bool) bool {
+	if !prereleaseCheck(v, c, includePrerelease) || v.LessThan(c) {
+		return false
+	}
+
+	// The left-most non-zero segment (of those specified) must match,
+	// as must every segment before it.
+	switch {
+	case c.Major() > 0 || c.si < 2:
+		return v.Major() == c.Major()
+	case c.Minor() > 0 || c.si < 3:
+		return v.Major() == c.Major() && v.Minor() == c.Minor()
+	default:
+		return v.Major() == c.Major() && v.Minor() == c.Minor() && v.Patch() == c.Patch()
+	}
+}
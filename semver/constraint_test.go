@@ -26,6 +26,11 @@ func TestNewConstraint(t *testing.T) {
 		{"1.0", 1, false},
 		{">= 1.x", 0, true},
 		{">= 1.2, < 1.0", 2, false},
+		{"~1.4", 1, false},
+		{"^1.2.3", 1, false},
+		{"^ 0.2, != 0.2.5", 2, false},
+		{"~", 0, true},
+		{"=> 1.2", 0, true},
 
 		// Out of bounds
 		{"11387778780781445675529500000000000000000", 0, true},
@@ -82,6 +87,36 @@ func TestConstraintCheck(t *testing.T) {
 		{">= 2.1.0-a", "2.1.1-beta", false},
 		{">= 2.1.0-a", "2.1.0", true},
 		{"<= 2.1.0-a", "2.0.0", true},
+		{"~1", "1.0.0", true},
+		{"~1", "1.9.9", true},
+		{"~1", "2.0.0", false},
+		{"~1.4", "1.4.0", true},
+		{"~1.4", "1.4.9", true},
+		{"~1.4", "1.5.0", false},
+		{"~1.4", "1.3.9", false},
+		{"~1.2.3", "1.2.3", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.2.2", false},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2.3", "1.2.4-beta", false},
+		{"^1.2.3", "1.2.3", true},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "1.2.2", false},
+		{"^1.2.3", "2.0.0", false},
+		{"^0.2.3", "0.2.3", true},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^0", "0.9.9", true},
+		{"^0", "1.0.0", false},
+		{"^1", "1.9.9", true},
+		{"^1", "2.0.0", false},
+		{"^2.1.0-a", "2.1.0-beta", true},
+		{"^2.1.0-a", "2.1.1-beta", false},
+		{"^2.1.0-a", "2.1.1", true},
 	}
 
 	for _, tc := range cases {
@@ -97,6 +132,44 @@ func TestConstraintCheck(t *testing.T) {
 	}
 }
 
+func TestConstraintCheckPrerelease(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		constraint string
+		version    string
+		check      bool
+		prerelease bool
+	}{
+		{">= 1.0.0", "2.0.0-beta", false, true},
+		{">= 1.0.0", "0.9.0-beta", false, false},
+		{">= 1.0.0, < 2.0.0", "2.0.0-beta", false, true},
+		{">= 1.0.0-0", "1.0.0-beta", true, true},
+		{">= 1.0.0-0", "1.1.0-beta", false, true},
+		{">= 1.0.0-0", "1.0.0", true, true},
+		{"> 2.0", "2.1.0-beta", false, true},
+		{"<= 2.0", "2.0.0-beta", false, true},
+		{"~> 2.0", "2.1.0-beta", false, true},
+		{"~1.4", "1.4.5-beta", false, true},
+		{"~1.4", "1.5.0-beta", false, false},
+		{"^1.2.3", "1.3.0-rc.1", false, true},
+		{"^1.2.3", "2.0.0-rc.1", false, false},
+		{"= 1.0.0", "1.0.0-beta", false, false},
+		{"!= 1.0.0", "1.0.0-beta", true, true},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.constraint)
+		assert.Nil(err)
+
+		v, err := NewVersion(tc.version)
+		assert.Nil(err)
+
+		assert.Equal(tc.check, c.Check(v), tc.constraint, tc.version)
+		assert.Equal(tc.prerelease, c.CheckPrerelease(v), tc.constraint, tc.version)
+	}
+}
+
 func TestConstraintsString(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,81 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// constraintWildcardRegexp matches a constraint with up to three segments, any of which
+// may be a wildcard (`x`, `X` or `*`), with an optional "=" operator.
+var constraintWildcardRegexp = regexp.MustCompile(`^\s*=?\s*v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?\s*$`)
+
+// isWildcard returns if a constraint segment is a wildcard.
+func isWildcard(segment string) bool {
+	return segment == "x" || segment == "X" || segment == "*"
+}
+
+// parseWildcard parses a constraint with wildcard segments, e.g. "1.2.x" or "1.*",
+// into the equivalent range, e.g. ">=1.2.0, <1.3.0" or ">=1.0.0, <2.0.0".
+//
+// It returns false if the constraint does not include a wildcard.
+func parseWildcard(v string) (Constraints, bool, error) {
+	matches := constraintWildcardRegexp.FindStringSubmatch(v)
+	if matches == nil {
+		return nil, false, nil
+	}
+
+	// the index of the first wildcard segment
+	wildcard := -1
+	segments := make([]int64, 2)
+	for i, segment := range matches[1:] {
+		if segment == "" || isWildcard(segment) {
+			if wildcard < 0 && segment != "" {
+				wildcard = i
+			}
+			continue
+		}
+		if wildcard >= 0 {
+			return nil, true, fmt.Errorf("malformed constraint: %s; segments after a wildcard must be wildcards", v)
+		}
+		if i < len(segments) {
+			parsed, err := strconv.ParseInt(segment, 10, 64)
+			if err != nil {
+				return nil, true, fmt.Errorf("malformed constraint: %s; %v", v, err)
+			}
+			segments[i] = parsed
+		}
+	}
+	if wildcard < 0 {
+		return nil, false, nil
+	}
+
+	var lower, upper string
+	switch wildcard {
+	case 0:
+		return Constraints{mustParseSingle(">=0.0.0")}, true, nil
+	case 1:
+		lower = fmt.Sprintf(">=%d.0.0", segments[0])
+		upper = fmt.Sprintf("<%d.0.0", segments[0]+1)
+	default:
+		lower = fmt.Sprintf(">=%d.%d.0", segments[0], segments[1])
+		upper = fmt.Sprintf("<%d.%d.0", segments[0], segments[1]+1)
+	}
+	return Constraints{mustParseSingle(lower), mustParseSingle(upper)}, true, nil
+}
+
+// mustParseSingle parses a single constraint and panics on error.
+func mustParseSingle(v string) *Constraint {
+	c, err := parseSingle(v)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
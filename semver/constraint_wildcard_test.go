@@ -0,0 +1,81 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestNewConstraintWildcard(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{"1.2.x", ">=1.2.0,<1.3.0", false},
+		{"1.2.X", ">=1.2.0,<1.3.0", false},
+		{"= 1.2.*", ">=1.2.0,<1.3.0", false},
+		{"1.x", ">=1.0.0,<2.0.0", false},
+		{"1.*", ">=1.0.0,<2.0.0", false},
+		{"v1.x.x", ">=1.0.0,<2.0.0", false},
+		{"*", ">=0.0.0", false},
+		{"x.x.x", ">=0.0.0", false},
+		{"1.x, != 1.5.0", ">=1.0.0,<2.0.0, != 1.5.0", false},
+		{"1.x.3", "", true},
+		{"*.2", "", true},
+		{">= 1.x", "", true},
+		{"1.2.x-beta", "", true},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.input)
+		if tc.err {
+			assert.NotNil(err, fmt.Sprintf("expected error for input: %s", tc.input))
+			continue
+		}
+		assert.Nil(err, fmt.Sprintf("error for input %s: %v", tc.input, err))
+		assert.Equal(tc.expected, c.String(), tc.input)
+	}
+}
+
+func TestConstraintWildcardCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		constraint string
+		version    string
+		check      bool
+	}{
+		{"1.2.x", "1.2.0", true},
+		{"1.2.x", "1.2.9", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.2.x", "1.1.9", false},
+		{"1.2.x", "1.2.1-beta", false},
+		{"1.x", "1.0.0", true},
+		{"1.x", "1.9.9", true},
+		{"1.x", "2.0.0", false},
+		{"1.x", "0.9.0", false},
+		{"0.x", "0.0.0", true},
+		{"*", "0.0.0", true},
+		{"*", "1.2.3", true},
+		{"*", "1.2.3-beta", false},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.constraint)
+		assert.Nil(err)
+		v, err := NewVersion(tc.version)
+		assert.Nil(err)
+		assert.Equal(tc.check, c.Check(v), fmt.Sprintf("constraint: %s\nversion: %s", tc.constraint, tc.version))
+	}
+}
@@ -0,0 +1,74 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+// DiffKind is the most significant part that differs between two versions.
+//
+// Kinds are ordered by significance, so they can be compared, e.g. `kind >= DiffMinor`.
+type DiffKind int
+
+// DiffKinds
+const (
+	DiffNone DiffKind = iota
+	DiffPrerelease
+	DiffPatch
+	DiffMinor
+	DiffMajor
+)
+
+// String returns the name of the diff kind.
+func (dk DiffKind) String() string {
+	switch dk {
+	case DiffNone:
+		return "none"
+	case DiffPrerelease:
+		return "prerelease"
+	case DiffPatch:
+		return "patch"
+	case DiffMinor:
+		return "minor"
+	case DiffMajor:
+		return "major"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffType returns the most significant part that differs between the version and another version.
+//
+// Segments are checked in order, so "1.2.3" and "2.0.0" differ by `DiffMajor`, and segments after
+// the patch segment count as `DiffPatch`. If all segments are equal, differing prereleases are
+// `DiffPrerelease`. Metadata is ignored, so "1.2.3+a" and "1.2.3+b" differ by `DiffNone`.
+func (v *Version) DiffType(other *Version) DiffKind {
+	segments, otherSegments := v.Segments64(), other.Segments64()
+	for index := 0; index < len(segments) || index < len(otherSegments); index++ {
+		if diffSegment(segments, index) == diffSegment(otherSegments, index) {
+			continue
+		}
+		switch index {
+		case 0:
+			return DiffMajor
+		case 1:
+			return DiffMinor
+		default:
+			return DiffPatch
+		}
+	}
+	if v.Prerelease() != other.Prerelease() {
+		return DiffPrerelease
+	}
+	return DiffNone
+}
+
+// diffSegment returns a given segment, treating missing segments as zero.
+func diffSegment(segments []int64, index int) int64 {
+	if index < len(segments) {
+		return segments[index]
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestVersionDiffType(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		from     string
+		to       string
+		expected DiffKind
+	}{
+		{"1.2.3", "1.2.3", DiffNone},
+		{"1.2.3+a", "1.2.3+b", DiffNone},
+		{"v1.2", "1.2.0", DiffNone},
+		{"1.2.3", "2.0.0", DiffMajor},
+		{"2.0.0", "1.9.9", DiffMajor},
+		{"1.2.3", "2.2.3-rc.1", DiffMajor},
+		{"1.2.3", "1.3.0", DiffMinor},
+		{"1.2.3-rc.1", "1.3.0", DiffMinor},
+		{"1.2.3", "1.2.4", DiffPatch},
+		{"1.2.3", "1.2.3.1", DiffPatch},
+		{"1.2.3-rc.1", "1.2.4+build", DiffPatch},
+		{"1.2.3-rc.1", "1.2.3-rc.2", DiffPrerelease},
+		{"1.2.3-rc.1", "1.2.3", DiffPrerelease},
+		{"1.2.3", "1.2.3-beta+build", DiffPrerelease},
+	}
+
+	for _, tc := range cases {
+		from, to := Must(NewVersion(tc.from)), Must(NewVersion(tc.to))
+		assert.Equal(tc.expected, from.DiffType(to), tc.from+" -> "+tc.to)
+		assert.Equal(tc.expected, to.DiffType(from), tc.to+" -> "+tc.from)
+	}
+}
+
+func TestDiffKindString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("none", DiffNone.String())
+	assert.Equal("prerelease", DiffPrerelease.String())
+	assert.Equal("patch", DiffPatch.String())
+	assert.Equal("minor", DiffMinor.String())
+	assert.Equal("major", DiffMajor.String())
+	assert.Equal("unknown", DiffKind(100).String())
+	assert.True(DiffMinor > DiffPatch)
+}
@@ -0,0 +1,50 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLoosePrefixes are the prefixes stripped by `ParseLoose` if none are given.
+var DefaultLoosePrefixes = []string{"release-"}
+
+// ParseLoose parses a version from common loose formats, e.g. tags emitted by CI.
+//
+// It trims surrounding whitespace, strips the first matching prefix (`DefaultLoosePrefixes`
+// if none are given), and accepts a leading `v` or `V`. Missing minor and patch
+// segments are zero filled, so `v1` parses as `1.0.0`.
+//
+// Input that is malformed after the prefix is removed is still rejected;
+// use `NewVersion` for strict parsing.
+func ParseLoose(v string, prefixes ...string) (*Version, error) {
+	if len(prefixes) == 0 {
+		prefixes = DefaultLoosePrefixes
+	}
+
+	loose := strings.TrimSpace(v)
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(loose, prefix) {
+			loose = strings.TrimPrefix(loose, prefix)
+			break
+		}
+	}
+	if strings.HasPrefix(loose, "V") {
+		loose = "v" + loose[1:]
+	}
+	if loose == "" || loose == "v" {
+		return nil, fmt.Errorf("malformed version: %s", v)
+	}
+
+	version, err := NewVersion(loose)
+	if err != nil {
+		return nil, fmt.Errorf("malformed version: %s", v)
+	}
+	return version, nil
+}
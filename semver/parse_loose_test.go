@@ -0,0 +1,63 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestParseLoose(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		version  string
+		prefixes []string
+		expected string
+		err      bool
+	}{
+		{"v1", nil, "1.0.0", false},
+		{"V1", nil, "1.0.0", false},
+		{"1.2", nil, "1.2.0", false},
+		{"v1.2", nil, "1.2.0", false},
+		{"1.2.3", nil, "1.2.3", false},
+		{"release-1.2.3", nil, "1.2.3", false},
+		{"release-v1.2", nil, "1.2.0", false},
+		{" v1.2.3-rc1 \n", nil, "1.2.3-rc1", false},
+		{"build/1.2.3", []string{"build/"}, "1.2.3", false},
+		{"release-1.2.3", []string{"build/"}, "", true},
+		{"", nil, "", true},
+		{"v", nil, "", true},
+		{"release-", nil, "", true},
+		{"garbage", nil, "", true},
+		{"1.2.beta", nil, "", true},
+		{"foo1.2.3", nil, "", true},
+		{"release-release-1.2.3", nil, "", true},
+	}
+
+	for _, tc := range cases {
+		version, err := ParseLoose(tc.version, tc.prefixes...)
+		if tc.err {
+			assert.NotNil(err, tc.version)
+			assert.Nil(version, tc.version)
+			continue
+		}
+		assert.Nil(err, tc.version)
+		assert.Equal(tc.expected, version.String(), tc.version)
+	}
+}
+
+func TestParseLooseStrictUnchanged(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewVersion("release-1.2.3")
+	assert.NotNil(err)
+	_, err = NewVersion(" 1.2.3")
+	assert.NotNil(err)
+}
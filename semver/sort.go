@@ -0,0 +1,42 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+import "sort"
+
+// Sort returns a new slice of the versions sorted in ascending order.
+//
+// The input slice is not modified. Nil versions sort last.
+func Sort(in []*Version) []*Version {
+	output := copyVersions(in)
+	sort.Stable(Collection(output))
+	return output
+}
+
+// SortDescending returns a new slice of the versions sorted in descending order.
+//
+// The input slice is not modified. Nil versions sort last.
+func SortDescending(in []*Version) []*Version {
+	output := copyVersions(in)
+	sort.SliceStable(output, func(i, j int) bool {
+		if output[i] == nil || output[j] == nil {
+			return output[i] != nil && output[j] == nil
+		}
+		return output[i].GreaterThan(output[j])
+	})
+	return output
+}
+
+func copyVersions(in []*Version) []*Version {
+	if in == nil {
+		return nil
+	}
+	output := make([]*Version, len(in))
+	copy(output, in)
+	return output
+}
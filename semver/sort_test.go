@@ -0,0 +1,62 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package semver
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func versionStrings(versions []*Version) []string {
+	output := make([]string, len(versions))
+	for i, version := range versions {
+		if version != nil {
+			output[i] = version.String()
+		}
+	}
+	return output
+}
+
+func TestSort(t *testing.T) {
+	assert := assert.New(t)
+
+	in := []*Version{
+		Must(NewVersion("1.1.1")),
+		Must(NewVersion("1.0.0")),
+		nil,
+		Must(NewVersion("1.2.0")),
+		Must(NewVersion("0.7.1")),
+		Must(NewVersion("1.2.0-rc1")),
+	}
+	original := versionStrings(in)
+
+	sorted := Sort(in)
+	assert.Equal([]string{"0.7.1", "1.0.0", "1.1.1", "1.2.0-rc1", "1.2.0", ""}, versionStrings(sorted))
+	assert.Equal(original, versionStrings(in))
+
+	sorted[0] = nil
+	assert.NotNil(in[4])
+
+	descending := SortDescending(in)
+	assert.Equal([]string{"1.2.0", "1.2.0-rc1", "1.1.1", "1.0.0", "0.7.1", ""}, versionStrings(descending))
+	assert.Equal(original, versionStrings(in))
+
+	assert.Nil(Sort(nil))
+	assert.Nil(SortDescending(nil))
+	assert.Empty(Sort([]*Version{}))
+}
+
+func TestCollectionNilSortsLast(t *testing.T) {
+	assert := assert.New(t)
+
+	versions := Collection{nil, Must(NewVersion("2.0.0")), nil, Must(NewVersion("1.0.0"))}
+	sort.Sort(versions)
+	assert.Equal([]string{"1.0.0", "2.0.0", "", ""}, versionStrings(versions))
+}
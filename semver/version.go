@@ -9,6 +9,7 @@ package semver
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -164,51 +165,60 @@ func allZero(segs []int64) bool {
 	return true
 }
 
+// comparePart compares a single dot separated prerelease identifier
+// per the semver 2.0.0 precedence rules:
+//
+// - identifiers consisting of only digits are compared numerically
+// - identifiers with letters or hyphens are compared lexically in ASCII sort order
+// - numeric identifiers always have lower precedence than non-numeric identifiers
+// - a missing identifier (i.e. a smaller set of fields) has lower precedence
 func comparePart(preSelf string, preOther string) int {
 	if preSelf == preOther {
 		return 0
 	}
 
-	var selfInt int64
-	selfNumeric := true
-	selfInt, err := strconv.ParseInt(preSelf, 10, 64)
-	if err != nil {
-		selfNumeric = false
-	}
-
-	var otherInt int64
-	otherNumeric := true
-	otherInt, err = strconv.ParseInt(preOther, 10, 64)
-	if err != nil {
-		otherNumeric = false
-	}
-
-	// if a part is empty, we use the other to decide
+	// if a part is empty, the other has more fields and wins
 	if preSelf == "" {
-		if otherNumeric {
-			return -1
-		}
-		return 1
+		return -1
 	}
-
 	if preOther == "" {
-		if selfNumeric {
-			return 1
-		}
-		return -1
+		return 1
 	}
 
-	if selfNumeric && !otherNumeric {
+	selfInt, selfNumeric := parseNumericIdentifier(preSelf)
+	otherInt, otherNumeric := parseNumericIdentifier(preOther)
+
+	switch {
+	case selfNumeric && !otherNumeric:
 		return -1
-	} else if !selfNumeric && otherNumeric {
-		return 1
-	} else if !selfNumeric && !otherNumeric && preSelf > preOther {
+	case !selfNumeric && otherNumeric:
 		return 1
-	} else if selfInt > otherInt {
+	case !selfNumeric && !otherNumeric:
+		if preSelf > preOther {
+			return 1
+		}
+		return -1
+	case selfInt > otherInt:
 		return 1
+	case selfInt < otherInt:
+		return -1
 	}
+	return 0
+}
 
-	return -1
+// parseNumericIdentifier parses a prerelease identifier consisting only of
+// digits; identifiers with signs or other characters are not numeric.
+func parseNumericIdentifier(part string) (int64, bool) {
+	for _, r := range part {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	value, err := strconv.ParseInt(part, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
 }
 
 func comparePrereleases(v string, other string) int {
@@ -307,6 +317,18 @@ func (v *Version) Segments64() []int64 {
 	return v.segments
 }
 
+// Core returns a new version with the same numeric segments as this version,
+// but without any pre-release or metadata information. For example,
+// for a version "1.2.3-beta+build", core will return "1.2.3".
+func (v *Version) Core() *Version {
+	segments := make([]int64, len(v.segments))
+	copy(segments, v.segments)
+	return &Version{
+		segments: segments,
+		si:       v.si,
+	}
+}
+
 // String returns the full version string included pre-release
 // and metadata information.
 func (v *Version) String() string {
@@ -328,6 +350,58 @@ func (v *Version) String() string {
 	return buf.String()
 }
 
+// MarshalJSON implements json.Marshaler.
+//
+// The version is serialized as its canonical string form.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// An empty string or `null` leaves the version zero-valued.
+func (v *Version) UnmarshalJSON(contents []byte) error {
+	if string(bytes.TrimSpace(contents)) == "null" {
+		*v = Version{}
+		return nil
+	}
+	var raw string
+	if err := json.Unmarshal(contents, &raw); err != nil {
+		return err
+	}
+	if raw == "" {
+		*v = Version{}
+		return nil
+	}
+	parsed, err := NewVersion(raw)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// Empty text leaves the version zero-valued.
+func (v *Version) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = Version{}
+		return nil
+	}
+	parsed, err := NewVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
 // Major returns the Major segment, or the highest order segment.
 func (v *Version) Major() (major int64) {
 	if len(v.segments) < 1 {
@@ -376,6 +450,43 @@ func (v *Version) BumpPatch() {
 	v.metadata = ""
 }
 
+// BumpPrerelease advances the prerelease counter and resets the metadata.
+//
+// If the version already has a prerelease starting with the given label
+// (or label is empty), the trailing numeric identifier is incremented, or
+// ".1" is appended if the trailing identifier is not numeric; for example
+// "1.2.3-rc.1" becomes "1.2.3-rc.2". Otherwise the prerelease is set to the
+// label followed by ".1"; for example "1.2.3" becomes "1.2.3-rc.1".
+func (v *Version) BumpPrerelease(label string) error {
+	if label == "" && v.pre == "" {
+		return fmt.Errorf("cannot bump prerelease; version has no prerelease and no label was provided")
+	}
+	if label != "" && !prereleaseLabelRegexp.MatchString(label) {
+		return fmt.Errorf("invalid prerelease label: %s", label)
+	}
+
+	if label == "" || v.pre == label || strings.HasPrefix(v.pre, label+".") {
+		v.pre = bumpPrereleaseCounter(v.pre)
+	} else {
+		v.pre = label + ".1"
+	}
+	v.metadata = ""
+	return nil
+}
+
+var prereleaseLabelRegexp = regexp.MustCompile(`^[0-9A-Za-z\-~]+(\.[0-9A-Za-z\-~]+)*$`)
+
+func bumpPrereleaseCounter(pre string) string {
+	parts := strings.Split(pre, ".")
+	last := len(parts) - 1
+	counter, err := strconv.ParseInt(parts[last], 10, 64)
+	if err != nil {
+		return pre + ".1"
+	}
+	parts[last] = strconv.FormatInt(counter+1, 10)
+	return strings.Join(parts, ".")
+}
+
 // Collection is a type that implements the sort.Interface interface
 // so that versions can be sorted.
 type Collection []*Version
@@ -384,10 +495,45 @@ func (v Collection) Len() int {
 	return len(v)
 }
 
+// Less returns if the version at index i is less than the version at index j.
+// Nil versions are treated as greater than any version so that they sort last.
 func (v Collection) Less(i, j int) bool {
+	if v[i] == nil || v[j] == nil {
+		return v[i] != nil && v[j] == nil
+	}
 	return v[i].LessThan(v[j])
 }
 
 func (v Collection) Swap(i, j int) {
 	v[i], v[j] = v[j], v[i]
 }
+
+// Latest returns the greatest version in the collection without sorting it,
+// or nil if the collection is empty.
+func (v Collection) Latest() *Version {
+	var latest *Version
+	for _, version := range v {
+		if version == nil {
+			continue
+		}
+		if latest == nil || version.GreaterThan(latest) {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// LatestStable returns the greatest version in the collection that does not
+// have a prerelease, or nil if there is no such version.
+func (v Collection) LatestStable() *Version {
+	var latest *Version
+	for _, version := range v {
+		if version == nil || version.Prerelease() != "" {
+			continue
+		}
+		if latest == nil || version.GreaterThan(latest) {
+			latest = version
+		}
+	}
+	return latest
+}
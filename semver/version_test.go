@@ -8,10 +8,14 @@ Use of this source code is governed by a MIT license that can be found in the LI
 package semver
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/blend/go-sdk/assert"
 )
 
@@ -112,7 +116,7 @@ func TestComparePreReleases(t *testing.T) {
 		{"3.0-alpha.3", "3.0-rc.1", -1},
 		{"3.0-alpha3", "3.0-rc1", -1},
 		{"3.0-alpha.1", "3.0-alpha.beta", -1},
-		{"5.4-alpha", "5.4-alpha.beta", 1},
+		{"5.4-alpha", "5.4-alpha.beta", -1},
 		{"v1.2-beta.2", "v1.2-beta.2", 0},
 		{"v1.2-beta.1", "v1.2-beta.2", -1},
 		{"v3.2-alpha.1", "v3.2-alpha", 1},
@@ -132,6 +136,53 @@ func TestComparePreReleases(t *testing.T) {
 	}
 }
 
+func TestComparePreReleasesSpecOrdering(t *testing.T) {
+	assert := assert.New(t)
+
+	// the precedence example from https://semver.org/#spec-item-11
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		lower := Must(NewVersion(ordered[i]))
+		higher := Must(NewVersion(ordered[i+1]))
+		assert.Equal(-1, lower.Compare(higher), fmt.Sprintf("%s < %s", ordered[i], ordered[i+1]))
+		assert.Equal(1, higher.Compare(lower), fmt.Sprintf("%s > %s", ordered[i+1], ordered[i]))
+	}
+
+	for _, raw := range ordered {
+		v := Must(NewVersion(raw))
+		assert.Equal(0, v.Compare(Must(NewVersion(raw))), raw)
+	}
+
+	cases := []struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		{"1.0.0-1", "1.0.0-alpha", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.-1", -1},
+		{"1.0.0-alpha.9", "1.0.0-alpha.10", -1},
+		{"1.0.0-alpha.beta", "1.0.0-alpha.1.2", 1},
+		{"1.0.0-rc.1", "1.0.0-rc.1.0", -1},
+		{"1.0.0-Beta", "1.0.0-alpha", -1},
+	}
+	for _, tc := range cases {
+		v1 := Must(NewVersion(tc.v1))
+		v2 := Must(NewVersion(tc.v2))
+		assert.Equal(tc.expected, v1.Compare(v2), fmt.Sprintf("%s vs. %s", tc.v1, tc.v2))
+		assert.Equal(-tc.expected, v2.Compare(v1), fmt.Sprintf("%s vs. %s", tc.v2, tc.v1))
+	}
+}
+
 func TestVersionMetadata(t *testing.T) {
 	assert := assert.New(t)
 
@@ -249,6 +300,34 @@ func TestVersionString(t *testing.T) {
 	}
 }
 
+func TestVersionCore(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"1.2.3-beta", "1.2.3"},
+		{"1.2.3-beta.1+build.5", "1.2.3"},
+		{"v1.2+metadata", "1.2.0"},
+		{"1.2.3.4-rc1", "1.2.3.4"},
+	}
+
+	for _, tc := range cases {
+		v := Must(NewVersion(tc.version))
+		original := v.String()
+		core := v.Core()
+		assert.Equal(tc.expected, core.String())
+		assert.Empty(core.Prerelease())
+		assert.Empty(core.Metadata())
+
+		// the receiver is left untouched
+		core.BumpPatch()
+		assert.Equal(original, v.String())
+	}
+}
+
 func TestCollection(t *testing.T) {
 	assert := assert.New(t)
 
@@ -284,3 +363,127 @@ func TestCollection(t *testing.T) {
 
 	assert.Equal(expected, actual)
 }
+
+func TestCollectionLatest(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Collection{}.Latest())
+	assert.Nil(Collection{}.LatestStable())
+
+	versions := Collection{
+		Must(NewVersion("1.1.1")),
+		Must(NewVersion("2.1.0-beta")),
+		Must(NewVersion("0.7.1")),
+		Must(NewVersion("2.0")),
+		Must(NewVersion("1.2")),
+	}
+
+	assert.Equal("2.1.0-beta", versions.Latest().String())
+	assert.Equal("2.0.0", versions.LatestStable().String())
+	// the collection is not reordered
+	assert.Equal("1.1.1", versions[0].String())
+	assert.Equal("1.2.0", versions[4].String())
+
+	prereleases := Collection{
+		Must(NewVersion("1.0.0-rc.1")),
+		Must(NewVersion("1.0.0-rc.2")),
+	}
+	assert.Equal("1.0.0-rc.2", prereleases.Latest().String())
+	assert.Nil(prereleases.LatestStable())
+}
+
+func TestVersionJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	type payload struct {
+		Version  Version  `json:"version"`
+		Optional *Version `json:"optional,omitempty"`
+	}
+
+	contents, err := json.Marshal(payload{Version: *Must(NewVersion("v1.2.3-beta.1+build"))})
+	assert.Nil(err)
+	assert.Equal(`{"version":"1.2.3-beta.1+build"}`, string(contents))
+
+	var verify payload
+	assert.Nil(json.Unmarshal(contents, &verify))
+	assert.Equal("1.2.3-beta.1+build", verify.Version.String())
+	assert.Nil(verify.Optional)
+
+	assert.Nil(json.Unmarshal([]byte(`{"version":"2.0.0","optional":"1.0"}`), &verify))
+	assert.Equal("2.0.0", verify.Version.String())
+	assert.NotNil(verify.Optional)
+	assert.Equal("1.0.0", verify.Optional.String())
+
+	var empty payload
+	assert.Nil(json.Unmarshal([]byte(`{"version":""}`), &empty))
+	assert.Empty(empty.Version.Segments64())
+	assert.Nil(json.Unmarshal([]byte(`{"version":null}`), &empty))
+	assert.Empty(empty.Version.Segments64())
+
+	_, expectedErr := NewVersion("foo")
+	err = json.Unmarshal([]byte(`{"version":"foo"}`), &empty)
+	assert.NotNil(err)
+	assert.Equal(expectedErr.Error(), err.Error())
+}
+
+func TestVersionText(t *testing.T) {
+	assert := assert.New(t)
+
+	var _ encoding.TextMarshaler = (*Version)(nil)
+	var _ encoding.TextUnmarshaler = (*Version)(nil)
+
+	text, err := Must(NewVersion("v1.2.3-rc.1")).MarshalText()
+	assert.Nil(err)
+	assert.Equal("1.2.3-rc.1", string(text))
+
+	var verify Version
+	assert.Nil(verify.UnmarshalText(text))
+	assert.Equal("1.2.3-rc.1", verify.String())
+
+	assert.Nil(verify.UnmarshalText(nil))
+	assert.Empty(verify.Segments64())
+
+	assert.NotNil(verify.UnmarshalText([]byte("foo")))
+
+	type config struct {
+		Version Version `yaml:"version"`
+	}
+	var cfg config
+	assert.Nil(yaml.Unmarshal([]byte("version: 1.4.0\n"), &cfg))
+	assert.Equal("1.4.0", cfg.Version.String())
+}
+
+func TestVersionBumpPrerelease(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		version  string
+		label    string
+		expected string
+		err      bool
+	}{
+		{"1.2.3", "rc", "1.2.3-rc.1", false},
+		{"1.2.3-rc.1", "rc", "1.2.3-rc.2", false},
+		{"1.2.3-rc.9+build.5", "rc", "1.2.3-rc.10", false},
+		{"1.2.3-rc", "rc", "1.2.3-rc.1", false},
+		{"1.2.3-rc.1", "", "1.2.3-rc.2", false},
+		{"1.2.3-beta", "", "1.2.3-beta.1", false},
+		{"1.2.3-5", "", "1.2.3-6", false},
+		{"1.2.3-beta.2", "rc", "1.2.3-rc.1", false},
+		{"1.2.3-rcx.2", "rc", "1.2.3-rc.1", false},
+		{"1.2.3", "", "", true},
+		{"1.2.3", "rc!", "", true},
+		{"1.2.3", "rc..1", "", true},
+	}
+
+	for _, tc := range cases {
+		v := Must(NewVersion(tc.version))
+		err := v.BumpPrerelease(tc.label)
+		if tc.err {
+			assert.NotNil(err, tc.version, tc.label)
+			continue
+		}
+		assert.Nil(err)
+		assert.Equal(tc.expected, v.String())
+	}
+}
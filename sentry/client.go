@@ -239,7 +239,7 @@ func errFrames(err error) []raven.Frame {
 		return nil
 	}
 	pointers, ok := stacktrace.(ex.StackPointers)
-	if !ok {
+	if !ok || len(pointers) == 0 {
 		return nil
 	}
 
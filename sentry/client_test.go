@@ -122,6 +122,13 @@ func TestErrFrames(t *testing.T) {
 
 	err := ex.New("this is only a test")
 	assert.NotEmpty(errFrames(err))
+
+	err = ex.New("this is only a test", ex.OptStackDepth(1))
+	assert.Len(errFrames(err), 1)
+
+	err = ex.New("this is only a test", ex.OptSkipStack())
+	assert.Empty(errFrames(err))
+	assert.Nil(errStackTrace(err))
 }
 
 func TestErrEventFingerprintDefault(t *testing.T) {
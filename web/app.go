@@ -12,6 +12,7 @@ import (
 	"crypto/tls"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -316,6 +317,18 @@ func (a *App) MethodBare(method string, path string, action Action, middleware .
 	a.RouteTree.Handle(method, path, a.RenderActionBare(NestMiddleware(action, append(middleware, a.BaseMiddleware...)...)))
 }
 
+// Mount registers an http.Handler for every method and path under a given prefix, e.g. `/debug/`.
+//
+// The prefix is stripped from the request path before it is passed to the handler,
+// as with `http.StripPrefix`. Routes registered under the prefix take precedence over the mounted handler.
+func (a *App) Mount(prefix string, handler http.Handler, middleware ...Middleware) {
+	action := func(r *Ctx) Result {
+		handler.ServeHTTP(r.Response, mountedRequest(r.Request, r.RouteParams.Get(RouteTokenFilepath)))
+		return nil
+	}
+	a.RouteTree.Mount(prefix, a.RenderAction(NestMiddleware(action, append(middleware, a.BaseMiddleware...)...)))
+}
+
 // Lookup finds the route data for a given method and path.
 func (a *App) Lookup(method, path string) (route *Route, params RouteParameters, skipSlashRedirect bool) {
 	if root := a.RouteTree.Routes[method]; root != nil {
@@ -496,8 +509,16 @@ func (a *App) maybeLogFatal(ctx context.Context, err error, req *http.Request) {
 }
 
 func (a *App) logRequest(r *Ctx) {
+	// the request was already logged by the `Logged` middleware.
+	if r.StateValue(stateKeyRequestLogged) != nil {
+		return
+	}
+	a.maybeLogTrigger(r.Context(), r.Log, newHTTPRequestEvent(r, r.Response.StatusCode()))
+}
+
+func newHTTPRequestEvent(r *Ctx, statusCode int) webutil.HTTPRequestEvent {
 	requestEvent := webutil.NewHTTPRequestEvent(r.Request.Clone(r.Context()),
-		webutil.OptHTTPRequestStatusCode(r.Response.StatusCode()),
+		webutil.OptHTTPRequestStatusCode(statusCode),
 		webutil.OptHTTPRequestContentLength(r.Response.ContentLength()),
 		webutil.OptHTTPRequestHeader(r.Response.Header().Clone()),
 		webutil.OptHTTPRequestElapsed(r.Elapsed()),
@@ -509,7 +530,7 @@ func (a *App) logRequest(r *Ctx) {
 		requestEvent.ContentType = requestEvent.Header.Get(webutil.HeaderContentType)
 		requestEvent.ContentEncoding = requestEvent.Header.Get(webutil.HeaderContentEncoding)
 	}
-	a.maybeLogTrigger(r.Context(), r.Log, requestEvent)
+	return requestEvent
 }
 
 func (a *App) maybeLogTrigger(ctx context.Context, log logger.Log, e logger.Event) {
@@ -518,3 +539,15 @@ func (a *App) maybeLogTrigger(ctx context.Context, log logger.Log, e logger.Even
 	}
 	log.TriggerContext(ctx, e)
 }
+
+// mountedRequest returns a shallow copy of a request with the path set to the path
+// relative to a mount prefix, as with `http.StripPrefix`.
+func mountedRequest(req *http.Request, path string) *http.Request {
+	mounted := new(http.Request)
+	*mounted = *req
+	mounted.URL = new(url.URL)
+	*mounted.URL = *req.URL
+	mounted.URL.Path = path
+	mounted.URL.RawPath = ""
+	return mounted
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"testing"
@@ -136,6 +137,59 @@ func TestAppPathParams(t *testing.T) {
 	assert.Equal("foo", params.Get("uuid"))
 }
 
+func TestAppMount(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, "mux %s %s", req.Method, req.URL.Path)
+	})
+
+	app, err := New()
+	assert.Nil(err)
+	app.GET("/debug/vars", func(_ *Ctx) Result {
+		return Raw([]byte("vars"))
+	})
+	app.Mount("/debug", mux)
+	app.Mount("/debug/pprof/", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, "pprof %s", req.URL.Path)
+	}))
+
+	contents, meta, err := MockGet(app, "/debug/vars").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal("vars", string(contents))
+
+	contents, _, err = MockGet(app, "/debug/foo/bar").Bytes()
+	assert.Nil(err)
+	assert.Equal("mux GET /foo/bar", string(contents))
+
+	contents, _, err = MockGet(app, "/debug").Bytes()
+	assert.Nil(err)
+	assert.Equal("mux GET /", string(contents))
+
+	contents, _, err = MockMethod(app, http.MethodPost, "/debug/vars").Bytes()
+	assert.Nil(err)
+	assert.Equal("mux POST /vars", string(contents))
+
+	contents, _, err = MockGet(app, "/debug/pprof/heap").Bytes()
+	assert.Nil(err)
+	assert.Equal("pprof /heap", string(contents))
+
+	route, params := app.RouteTree.Route(&http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/debug/pprof/heap"}})
+	assert.NotNil(route)
+	assert.Equal("/debug/pprof/*filepath", route.Path)
+	assert.Equal(http.MethodGet, route.Method)
+	assert.Equal("/heap", params.Get(RouteTokenFilepath))
+
+	meta, err = MockGet(app, "/other").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, meta.StatusCode)
+
+	assert.PanicEqual("a handle is already mounted for prefix '/debug/'", func() { app.Mount("/debug/", mux) })
+	assert.PanicEqual("mount prefix must begin with '/' in prefix 'debug'", func() { app.Mount("debug", mux) })
+}
+
 func TestAppPathParamsForked(t *testing.T) {
 	/*
 		this test should assert that we can have a common structure of routes
@@ -328,6 +382,35 @@ func TestAppProviderMiddlewareOrder(t *testing.T) {
 	assert.Nil(err)
 }
 
+func TestAppRouteMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls []string
+	var record = func(name string) Middleware {
+		return func(action Action) Action {
+			return func(r *Ctx) Result {
+				calls = append(calls, name)
+				return action(r)
+			}
+		}
+	}
+
+	app, err := New(OptUse(record("base")))
+	assert.Nil(err)
+
+	app.GET("/", func(_ *Ctx) Result { return Raw([]byte("OK!")) }, record("inner"), record("outer"))
+	app.GET("/bare", func(_ *Ctx) Result { return Raw([]byte("OK!")) })
+
+	_, err = MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal([]string{"base", "outer", "inner"}, calls)
+
+	calls = nil
+	_, err = MockGet(app, "/bare").Discard()
+	assert.Nil(err)
+	assert.Equal([]string{"base"}, calls)
+}
+
 func TestAppDefaultResultProviderWithDefault(t *testing.T) {
 	assert := assert.New(t)
 
@@ -767,3 +850,27 @@ func TestAppMethodBare(t *testing.T) {
 	agent.Drain()
 	assert.Empty(buffer.String())
 }
+
+func TestAppAutoHead(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/status", func(_ *Ctx) Result {
+		return JSON.Result(map[string]string{"status": "ok"})
+	})
+
+	contents, meta, err := MockMethod(app, http.MethodHead, "/status").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal(webutil.ContentTypeApplicationJSON, meta.Header.Get(webutil.HeaderContentType))
+	assert.NotEqual("", meta.Header.Get(webutil.HeaderContentLength))
+	assert.Empty(contents)
+
+	app = MustNew(OptAutoHead(false))
+	app.GET("/status", func(_ *Ctx) Result {
+		return JSON.Result(map[string]string{"status": "ok"})
+	})
+	meta, err = MockMethod(app, http.MethodHead, "/status").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusMethodNotAllowed, meta.StatusCode)
+}
@@ -0,0 +1,27 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+// Validatable is a type that can validate itself after it's bound from a request.
+type Validatable interface {
+	Validate() error
+}
+
+// BindOptions are options for binding request bodies.
+type BindOptions struct {
+	// DisallowUnknownFields causes binding to fail if the body has fields the destination doesn't.
+	DisallowUnknownFields bool
+}
+
+// BindOption mutates bind options.
+type BindOption func(*BindOptions)
+
+// OptBindDisallowUnknownFields sets if binding should fail for unknown fields.
+func OptBindDisallowUnknownFields(disallowUnknownFields bool) BindOption {
+	return func(bo *BindOptions) { bo.DisallowUnknownFields = disallowUnknownFields }
+}
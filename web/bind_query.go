@@ -0,0 +1,98 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+const (
+	// BindQueryTagName is the struct tag that maps a query parameter to a field for `BindQuery`.
+	BindQueryTagName = "url"
+	// BindQueryDefaultTagName is the struct tag that holds the default value of a field for `BindQuery`.
+	BindQueryDefaultTagName = "default"
+)
+
+var typeDuration = reflect.TypeOf(time.Duration(0))
+
+// bindQuery sets the fields of a given struct pointer from query values.
+func bindQuery(query url.Values, dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() != reflect.Struct {
+		return ex.New("bind query; destination must be a non-nil pointer to a struct", ex.OptMessagef("destination type: %T", dest))
+	}
+	destValue = destValue.Elem()
+	destType := destValue.Type()
+
+	for index := 0; index < destType.NumField(); index++ {
+		field := destType.Field(index)
+		key, ok := field.Tag.Lookup(BindQueryTagName)
+		if !ok || key == "-" || field.PkgPath != "" {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+
+		value := query.Get(key)
+		if value == "" {
+			defaultValue, hasDefault := field.Tag.Lookup(BindQueryDefaultTagName)
+			if !hasDefault {
+				continue
+			}
+			value = defaultValue
+		}
+		if err := bindQueryValue(destValue.Field(index), key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindQueryValue coerces a query value into a given field.
+func bindQueryValue(field reflect.Value, key, value string) error {
+	if field.Type() == typeDuration {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return NewParameterInvalidError(key, "must be a duration")
+		}
+		field.SetInt(int64(parsed))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := BoolValue(value, nil)
+		if err != nil {
+			return NewParameterInvalidError(key, "must be a boolean")
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return NewParameterInvalidError(key, "must be an integer")
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return NewParameterInvalidError(key, "must be a non-negative integer")
+		}
+		field.SetUint(parsed)
+	default:
+		return ex.New("bind query; unsupported field type", ex.OptMessagef("parameter: %s, type: %v", key, field.Type()))
+	}
+	return nil
+}
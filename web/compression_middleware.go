@@ -0,0 +1,114 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+var (
+	_ ResponseWriter = (*webutil.CompressionResponseWriter)(nil)
+)
+
+// DefaultCompressionContentTypes are the content type prefixes that are compressed by default.
+var DefaultCompressionContentTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/x-javascript",
+	"image/svg+xml",
+}
+
+// CompressionOption mutates compression options.
+type CompressionOption func(*webutil.CompressionOptions)
+
+// OptCompressionMinSize sets the minimum response body size in bytes that will be compressed.
+func OptCompressionMinSize(minSize int) CompressionOption {
+	return func(co *webutil.CompressionOptions) { co.MinSize = minSize }
+}
+
+// OptCompressionLevel sets the compression level.
+func OptCompressionLevel(level int) CompressionOption {
+	return func(co *webutil.CompressionOptions) { co.Level = level }
+}
+
+// OptCompressionContentTypes sets the content type prefixes that will be compressed.
+func OptCompressionContentTypes(contentTypes ...string) CompressionOption {
+	return func(co *webutil.CompressionOptions) { co.ContentTypes = contentTypes }
+}
+
+// Compression returns a middleware that compresses responses with gzip or deflate
+// based on the request `Accept-Encoding` header.
+//
+// Responses are only compressed if their content type is compressible, they don't
+// already have a content encoding, and the body is at least `MinSize` bytes.
+// Flushing the response (e.g. for streaming results) writes out whatever has been
+// buffered and compresses the rest of the response.
+//
+// It supersedes `GZip`, and uses `webutil.CompressionResponseWriter` to compress responses.
+func Compression(opts ...CompressionOption) Middleware {
+	options := webutil.CompressionOptions{
+		MinSize:      DefaultCompressionMinSize,
+		Level:        gzip.DefaultCompression,
+		ContentTypes: DefaultCompressionContentTypes,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			encoding := negotiateContentEncoding(r.Request.Header.Get(webutil.HeaderAcceptEncoding))
+			if encoding == "" || r.Request.Method == http.MethodHead {
+				return action(r)
+			}
+			r.Response = webutil.NewCompressionResponseWriter(r.Response, encoding, options)
+			return action(r)
+		}
+	}
+}
+
+// negotiateContentEncoding returns the preferred supported content encoding
+// from an accept encoding header value, or an empty string if none are acceptable.
+//
+// Gzip is preferred over deflate when both have the same quality.
+func negotiateContentEncoding(acceptEncoding string) (encoding string) {
+	var bestQuality float64
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		pieces := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(pieces[0]))
+		quality := 1.0
+		for _, param := range pieces[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		switch name {
+		case webutil.ContentEncodingGZIP, "*":
+			if quality > bestQuality || (quality == bestQuality && encoding != webutil.ContentEncodingGZIP) {
+				encoding, bestQuality = webutil.ContentEncodingGZIP, quality
+			}
+		case webutil.ContentEncodingDeflate:
+			if quality > bestQuality {
+				encoding, bestQuality = webutil.ContentEncodingDeflate, quality
+			}
+		}
+	}
+	return
+}
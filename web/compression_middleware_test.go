@@ -0,0 +1,143 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func largeJSON(_ *Ctx) Result {
+	return JSON.Result(map[string]string{"payload": strings.Repeat("a", 2*DefaultCompressionMinSize)})
+}
+
+func TestCompressionGZip(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(Compression()))
+	app.GET("/", largeJSON)
+
+	body, meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderAcceptEncoding, "deflate, gzip")).Bytes()
+	assert.Nil(err)
+	assert.Equal(webutil.ContentEncodingGZIP, meta.Header.Get(webutil.HeaderContentEncoding))
+	assert.Equal(webutil.HeaderAcceptEncoding, meta.Header.Get(webutil.HeaderVary))
+	assert.Equal(webutil.ContentTypeApplicationJSON, meta.Header.Get(webutil.HeaderContentType))
+
+	decompressor, err := gzip.NewReader(bytes.NewReader(body))
+	assert.Nil(err)
+	decompressed, err := ioutil.ReadAll(decompressor)
+	assert.Nil(err)
+	assert.Contains(string(decompressed), strings.Repeat("a", 2*DefaultCompressionMinSize))
+}
+
+func TestCompressionDeflate(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(Compression()))
+	app.GET("/", largeJSON)
+
+	body, meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderAcceptEncoding, "gzip;q=0.5, deflate")).Bytes()
+	assert.Nil(err)
+	assert.Equal(webutil.ContentEncodingDeflate, meta.Header.Get(webutil.HeaderContentEncoding))
+
+	decompressor, err := zlib.NewReader(bytes.NewReader(body))
+	assert.Nil(err)
+	decompressed, err := ioutil.ReadAll(decompressor)
+	assert.Nil(err)
+	assert.Contains(string(decompressed), strings.Repeat("a", 2*DefaultCompressionMinSize))
+}
+
+func TestCompressionMinSize(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(Compression()))
+	app.GET("/", ok)
+
+	body, meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderAcceptEncoding, "gzip")).Bytes()
+	assert.Nil(err)
+	assert.Empty(meta.Header.Get(webutil.HeaderContentEncoding))
+	assert.Equal(webutil.HeaderAcceptEncoding, meta.Header.Get(webutil.HeaderVary))
+	assert.Equal("\"OK!\"\n", string(body))
+
+	app = MustNew(OptUse(Compression(OptCompressionMinSize(0))))
+	app.GET("/", ok)
+
+	_, meta, err = MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderAcceptEncoding, "gzip")).Bytes()
+	assert.Nil(err)
+	assert.Equal(webutil.ContentEncodingGZIP, meta.Header.Get(webutil.HeaderContentEncoding))
+}
+
+func TestCompressionNotRequested(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(Compression()))
+	app.GET("/", largeJSON)
+
+	body, meta, err := MockGet(app, "/").Bytes()
+	assert.Nil(err)
+	assert.Empty(meta.Header.Get(webutil.HeaderContentEncoding))
+	assert.Contains(string(body), strings.Repeat("a", 2*DefaultCompressionMinSize))
+}
+
+func TestCompressionNotCompressible(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(Compression()))
+	app.GET("/", func(_ *Ctx) Result {
+		return RawWithContentType("image/png", bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, DefaultCompressionMinSize))
+	})
+
+	body, meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderAcceptEncoding, "gzip")).Bytes()
+	assert.Nil(err)
+	assert.Empty(meta.Header.Get(webutil.HeaderContentEncoding))
+	assert.Len(body, 4*DefaultCompressionMinSize)
+}
+
+func TestCompressionNoContent(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(Compression(OptCompressionMinSize(0))))
+	app.GET("/", func(_ *Ctx) Result { return NoContent })
+
+	body, meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderAcceptEncoding, "gzip")).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusNoContent, meta.StatusCode)
+	assert.Empty(meta.Header.Get(webutil.HeaderContentEncoding))
+	assert.Empty(body)
+}
+
+func TestNegotiateContentEncoding(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := [...]struct {
+		Input    string
+		Expected string
+	}{
+		{Input: "", Expected: ""},
+		{Input: "gzip", Expected: "gzip"},
+		{Input: "deflate", Expected: "deflate"},
+		{Input: "deflate, gzip", Expected: "gzip"},
+		{Input: "gzip;q=0.5, deflate", Expected: "deflate"},
+		{Input: "gzip;q=0, deflate;q=0", Expected: ""},
+		{Input: "br", Expected: ""},
+		{Input: "*", Expected: "gzip"},
+		{Input: "identity", Expected: ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(tc.Expected, negotiateContentEncoding(tc.Input), tc.Input)
+	}
+}
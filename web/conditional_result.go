@@ -0,0 +1,136 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+var (
+	_ Result           = (*ConditionalResult)(nil)
+	_ ResultPreRender  = (*ConditionalResult)(nil)
+	_ ResultPostRender = (*ConditionalResult)(nil)
+)
+
+// WithETag returns a result that sets the `ETag` header for a given result, and
+// renders a `304 Not Modified` in place of the result if the request's
+// `If-None-Match` header matches the etag.
+//
+// The etag is quoted if it isn't already.
+func WithETag(result Result, etag string) *ConditionalResult {
+	cr := conditionalResult(result)
+	cr.ETag = etag
+	return cr
+}
+
+// WithLastModified returns a result that sets the `Last-Modified` header for a given result,
+// and renders a `304 Not Modified` in place of the result if the request's
+// `If-Modified-Since` header is at or after the modification time.
+//
+// It can be combined with `WithETag`, in which case `If-None-Match` takes precedence.
+func WithLastModified(result Result, lastModified time.Time) *ConditionalResult {
+	cr := conditionalResult(result)
+	cr.LastModified = lastModified
+	return cr
+}
+
+func conditionalResult(result Result) *ConditionalResult {
+	if typed, ok := result.(*ConditionalResult); ok {
+		return typed
+	}
+	return &ConditionalResult{Result: result}
+}
+
+// ConditionalResult is a result that supports conditional `GET` and `HEAD` requests.
+type ConditionalResult struct {
+	Result       Result
+	ETag         string
+	LastModified time.Time
+}
+
+// PreRender calls the pre-render step of the wrapped result if it has one.
+func (cr *ConditionalResult) PreRender(ctx *Ctx) error {
+	if typed, ok := cr.Result.(ResultPreRender); ok {
+		return typed.PreRender(ctx)
+	}
+	return nil
+}
+
+// Render sets the validator headers, and either writes a not modified response
+// or renders the wrapped result.
+func (cr *ConditionalResult) Render(ctx *Ctx) error {
+	header := ctx.Response.Header()
+	etag := cr.quotedETag()
+	if etag != "" {
+		header.Set(webutil.HeaderETag, etag)
+	}
+	if !cr.LastModified.IsZero() {
+		header.Set(webutil.HeaderLastModified, cr.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if cr.notModified(ctx.Request) {
+		header.Del(webutil.HeaderContentType)
+		header.Del(webutil.HeaderContentLength)
+		ctx.Response.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	if cr.Result == nil {
+		return nil
+	}
+	return cr.Result.Render(ctx)
+}
+
+// PostRender calls the post-render step of the wrapped result if it has one.
+func (cr *ConditionalResult) PostRender(ctx *Ctx) error {
+	if typed, ok := cr.Result.(ResultPostRender); ok {
+		return typed.PostRender(ctx)
+	}
+	return nil
+}
+
+// notModified returns if a given request's conditional headers match the result.
+func (cr *ConditionalResult) notModified(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if ifNoneMatch := req.Header.Get(webutil.HeaderIfNoneMatch); ifNoneMatch != "" {
+		return cr.ETag != "" && etagMatches(ifNoneMatch, cr.quotedETag())
+	}
+	if ifModifiedSince := req.Header.Get(webutil.HeaderIfModifiedSince); ifModifiedSince != "" && !cr.LastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		// http dates have second precision.
+		return !cr.LastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+func (cr *ConditionalResult) quotedETag() string {
+	if cr.ETag == "" || strings.HasSuffix(cr.ETag, `"`) {
+		return cr.ETag
+	}
+	return `"` + cr.ETag + `"`
+}
+
+// etagMatches returns if an `If-None-Match` header value matches a given etag
+// using the weak comparison function.
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestWithETag(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/", func(_ *Ctx) Result {
+		return WithETag(JSON.Result(map[string]string{"foo": "bar"}), "abcd")
+	})
+
+	body, meta, err := MockGet(app, "/").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal(`"abcd"`, meta.Header.Get(webutil.HeaderETag))
+	assert.Equal("{\"foo\":\"bar\"}\n", string(body))
+
+	body, meta, err = MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderIfNoneMatch, `"wxyz", W/"abcd"`)).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotModified, meta.StatusCode)
+	assert.Equal(`"abcd"`, meta.Header.Get(webutil.HeaderETag))
+	assert.Empty(body)
+
+	meta, err = MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderIfNoneMatch, `"wxyz"`)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+}
+
+func TestWithLastModified(t *testing.T) {
+	assert := assert.New(t)
+
+	lastModified := time.Date(2021, 01, 02, 03, 04, 05, 600, time.UTC)
+	app := MustNew()
+	app.GET("/", func(_ *Ctx) Result {
+		return WithLastModified(WithETag(JSON.OK(), `"abcd"`), lastModified)
+	})
+
+	meta, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal(lastModified.Format(http.TimeFormat), meta.Header.Get(webutil.HeaderLastModified))
+	assert.Equal(`"abcd"`, meta.Header.Get(webutil.HeaderETag))
+
+	meta, err = MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderIfModifiedSince, lastModified.Format(http.TimeFormat))).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotModified, meta.StatusCode)
+
+	meta, err = MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderIfModifiedSince, lastModified.Add(-time.Hour).Format(http.TimeFormat))).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+
+	// if-none-match takes precedence over if-modified-since.
+	meta, err = MockGet(app, "/",
+		r2.OptHeaderValue(webutil.HeaderIfNoneMatch, `"wxyz"`),
+		r2.OptHeaderValue(webutil.HeaderIfModifiedSince, lastModified.Format(http.TimeFormat)),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+}
+
+func TestWithETagNotGet(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.POST("/", func(_ *Ctx) Result {
+		return WithETag(JSON.OK(), "abcd")
+	})
+
+	meta, err := MockMethod(app, http.MethodPost, "/", r2.OptHeaderValue(webutil.HeaderIfNoneMatch, `"abcd"`)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+}
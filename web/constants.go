@@ -38,6 +38,8 @@ const (
 	DefaultHandleMethodNotAllowed = false
 	// DefaultRecoverPanics returns if we should recover panics by default.
 	DefaultRecoverPanics = true
+	// DefaultCompressionMinSize is the default minimum response body size in bytes that will be compressed.
+	DefaultCompressionMinSize = 1024
 	// DefaultMaxHeaderBytes is a default that is unset.
 	DefaultMaxHeaderBytes = 0
 	// DefaultReadTimeout is a default.
@@ -0,0 +1,172 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+// DefaultCORSAllowedMethods are the default methods allowed for cross origin requests.
+var DefaultCORSAllowedMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// DefaultCORSAllowedHeaders are the default headers allowed for cross origin requests.
+var DefaultCORSAllowedHeaders = []string{
+	webutil.HeaderAccept,
+	webutil.HeaderContentType,
+	webutil.HeaderOrigin,
+	"X-Requested-With",
+}
+
+// CORSOptions are options for the cors middleware.
+type CORSOptions struct {
+	// AllowedOrigins are the origins allowed to make cross origin requests.
+	// An origin of `*` allows any origin.
+	AllowedOrigins []string
+	// AllowedMethods are the methods returned for preflight requests.
+	AllowedMethods []string
+	// AllowedHeaders are the request headers returned for preflight requests.
+	AllowedHeaders []string
+	// ExposedHeaders are the response headers exposed to the client.
+	ExposedHeaders []string
+	// AllowCredentials sets if credentials (e.g. cookies) can be sent with requests.
+	AllowCredentials bool
+	// MaxAge is how long the results of a preflight request can be cached.
+	MaxAge time.Duration
+}
+
+// CORSOption mutates cors options.
+type CORSOption func(*CORSOptions)
+
+// OptCORSAllowedOrigins sets the allowed origins.
+func OptCORSAllowedOrigins(origins ...string) CORSOption {
+	return func(co *CORSOptions) { co.AllowedOrigins = origins }
+}
+
+// OptCORSAllowedMethods sets the allowed methods.
+func OptCORSAllowedMethods(methods ...string) CORSOption {
+	return func(co *CORSOptions) { co.AllowedMethods = methods }
+}
+
+// OptCORSAllowedHeaders sets the allowed request headers.
+func OptCORSAllowedHeaders(headers ...string) CORSOption {
+	return func(co *CORSOptions) { co.AllowedHeaders = headers }
+}
+
+// OptCORSExposedHeaders sets the exposed response headers.
+func OptCORSExposedHeaders(headers ...string) CORSOption {
+	return func(co *CORSOptions) { co.ExposedHeaders = headers }
+}
+
+// OptCORSAllowCredentials sets if credentials are allowed.
+func OptCORSAllowCredentials(allowCredentials bool) CORSOption {
+	return func(co *CORSOptions) { co.AllowCredentials = allowCredentials }
+}
+
+// OptCORSMaxAge sets the preflight max age.
+func OptCORSMaxAge(maxAge time.Duration) CORSOption {
+	return func(co *CORSOptions) { co.MaxAge = maxAge }
+}
+
+// CORS returns a middleware that adds cross origin resource sharing headers for allowed origins.
+//
+// The request origin is only echoed back if it matches one of the allowed origins exactly;
+// if the allowed origins include `*` the allow origin header is `*` and credentials are not
+// allowed, per the spec. Preflight (`OPTIONS`) requests are answered with a `204`.
+//
+// Because the app answers `OPTIONS` requests for registered routes automatically, use
+// `OptOptionsHandler` for preflight requests to reach the middleware:
+//
+//	cors := web.CORS(web.OptCORSAllowedOrigins("https://app.example.com"))
+//	app := web.MustNew(
+//		web.OptUse(cors),
+//		web.OptOptionsHandler(cors(func(_ *web.Ctx) web.Result { return web.NoContent })),
+//	)
+func CORS(opts ...CORSOption) Middleware {
+	options := CORSOptions{
+		AllowedMethods: DefaultCORSAllowedMethods,
+		AllowedHeaders: DefaultCORSAllowedHeaders,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			header := r.Response.Header()
+			webutil.HeaderAddVary(header, webutil.HeaderOrigin)
+
+			origin := r.Request.Header.Get(webutil.HeaderOrigin)
+			preflight := r.Request.Method == http.MethodOptions && r.Request.Header.Get(webutil.HeaderAccessControlRequestMethod) != ""
+			if origin == "" {
+				return action(r)
+			}
+
+			allowOrigin, ok := options.allowOrigin(origin)
+			if !ok {
+				if preflight {
+					return NoContent
+				}
+				return action(r)
+			}
+
+			header.Set(webutil.HeaderAccessControlAllowOrigin, allowOrigin)
+			if options.AllowCredentials && allowOrigin != "*" {
+				header.Set(webutil.HeaderAccessControlAllowCredentials, "true")
+			}
+			if !preflight {
+				if len(options.ExposedHeaders) > 0 {
+					header.Set(webutil.HeaderAccessControlExposeHeaders, strings.Join(options.ExposedHeaders, ", "))
+				}
+				return action(r)
+			}
+
+			webutil.HeaderAddVary(header, webutil.HeaderAccessControlRequestMethod)
+			webutil.HeaderAddVary(header, webutil.HeaderAccessControlRequestHeaders)
+			if len(options.AllowedMethods) > 0 {
+				header.Set(webutil.HeaderAccessControlAllowMethods, strings.Join(options.AllowedMethods, ", "))
+			}
+			if len(options.AllowedHeaders) > 0 {
+				header.Set(webutil.HeaderAccessControlAllowHeaders, strings.Join(options.AllowedHeaders, ", "))
+			}
+			if options.MaxAge > 0 {
+				header.Set(webutil.HeaderAccessControlMaxAge, strconv.Itoa(int(options.MaxAge/time.Second)))
+			}
+			return NoContent
+		}
+	}
+}
+
+// allowOrigin returns the allow origin header value for a given request origin,
+// and if the origin is allowed.
+func (co CORSOptions) allowOrigin(origin string) (string, bool) {
+	var wildcard bool
+	for _, allowed := range co.AllowedOrigins {
+		if allowed == "*" {
+			wildcard = true
+			continue
+		}
+		if strings.EqualFold(allowed, origin) {
+			return origin, true
+		}
+	}
+	if wildcard {
+		return "*", true
+	}
+	return "", false
+}
@@ -0,0 +1,119 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestCORSAllowedOrigin(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(CORS(
+		OptCORSAllowedOrigins("https://app.example.com"),
+		OptCORSAllowCredentials(true),
+		OptCORSExposedHeaders("X-Request-Id"),
+	)))
+	app.GET("/", ok)
+
+	body, meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderOrigin, "https://app.example.com")).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal("\"OK!\"\n", string(body))
+	assert.Equal("https://app.example.com", meta.Header.Get(webutil.HeaderAccessControlAllowOrigin))
+	assert.Equal("true", meta.Header.Get(webutil.HeaderAccessControlAllowCredentials))
+	assert.Equal("X-Request-Id", meta.Header.Get(webutil.HeaderAccessControlExposeHeaders))
+	assert.Equal(webutil.HeaderOrigin, meta.Header.Get(webutil.HeaderVary))
+}
+
+func TestCORSDisallowedOrigin(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(CORS(
+		OptCORSAllowedOrigins("https://app.example.com"),
+		OptCORSAllowCredentials(true),
+	)))
+	app.GET("/", ok)
+
+	body, meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderOrigin, "https://evil.example.com")).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal("\"OK!\"\n", string(body))
+	assert.Empty(meta.Header.Get(webutil.HeaderAccessControlAllowOrigin))
+	assert.Empty(meta.Header.Get(webutil.HeaderAccessControlAllowCredentials))
+}
+
+func TestCORSWildcard(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(CORS(
+		OptCORSAllowedOrigins("*"),
+		OptCORSAllowCredentials(true),
+	)))
+	app.GET("/", ok)
+
+	meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderOrigin, "https://anything.example.com")).Discard()
+	assert.Nil(err)
+	assert.Equal("*", meta.Header.Get(webutil.HeaderAccessControlAllowOrigin))
+	assert.Empty(meta.Header.Get(webutil.HeaderAccessControlAllowCredentials))
+}
+
+func TestCORSNoOrigin(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(CORS(OptCORSAllowedOrigins("*"))))
+	app.GET("/", ok)
+
+	meta, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Empty(meta.Header.Get(webutil.HeaderAccessControlAllowOrigin))
+}
+
+func TestCORSPreflight(t *testing.T) {
+	assert := assert.New(t)
+
+	cors := CORS(
+		OptCORSAllowedOrigins("https://app.example.com"),
+		OptCORSAllowedMethods(http.MethodGet, http.MethodPost),
+		OptCORSAllowedHeaders(webutil.HeaderContentType, webutil.HeaderAuthorization),
+		OptCORSMaxAge(10*time.Minute),
+	)
+	app := MustNew(
+		OptUse(cors),
+		OptOptionsHandler(cors(func(_ *Ctx) Result { return NoContent })),
+	)
+	app.POST("/widgets", ok)
+
+	meta, err := MockMethod(app, http.MethodOptions, "/widgets",
+		r2.OptHeaderValue(webutil.HeaderOrigin, "https://app.example.com"),
+		r2.OptHeaderValue(webutil.HeaderAccessControlRequestMethod, http.MethodPost),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNoContent, meta.StatusCode)
+	assert.Equal("https://app.example.com", meta.Header.Get(webutil.HeaderAccessControlAllowOrigin))
+	assert.Equal("GET, POST", meta.Header.Get(webutil.HeaderAccessControlAllowMethods))
+	assert.Equal("Content-Type, Authorization", meta.Header.Get(webutil.HeaderAccessControlAllowHeaders))
+	assert.Equal("600", meta.Header.Get(webutil.HeaderAccessControlMaxAge))
+	assert.Equal("POST, OPTIONS", meta.Header.Get(webutil.HeaderAllow))
+
+	meta, err = MockMethod(app, http.MethodOptions, "/widgets",
+		r2.OptHeaderValue(webutil.HeaderOrigin, "https://evil.example.com"),
+		r2.OptHeaderValue(webutil.HeaderAccessControlRequestMethod, http.MethodPost),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNoContent, meta.StatusCode)
+	assert.Empty(meta.Header.Get(webutil.HeaderAccessControlAllowOrigin))
+	assert.Empty(meta.Header.Get(webutil.HeaderAccessControlAllowMethods))
+}
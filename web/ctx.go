@@ -16,11 +16,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/blend/go-sdk/ex"
 	"github.com/blend/go-sdk/logger"
 	"github.com/blend/go-sdk/reflectutil"
+	"github.com/blend/go-sdk/uuid"
 )
 
 var (
@@ -101,6 +103,16 @@ func (rc *Ctx) WithContext(ctx context.Context) *Ctx {
 }
 
 // Context returns the context.
+//
+// It is derived from the request context, and as such is cancelled when the client's
+// connection closes (or the request is otherwise cancelled), making it suitable to pass
+// to downstream calls or to `select` on in long running actions:
+//
+//	select {
+//	case <-r.Context().Done():
+//		return nil
+//	case <-ticker.C:
+//	}
 func (rc *Ctx) Context() context.Context {
 	ctx := logger.WithLabels(rc.Request.Context(), logger.GetLabels(rc.Request.Context()))
 	ctx = logger.WithLabels(ctx, rc.Labels())
@@ -108,6 +120,19 @@ func (rc *Ctx) Context() context.Context {
 	return ctx
 }
 
+// Deadline returns the deadline of the request context, if one is set (e.g. by the `WithTimeout` middleware).
+func (rc *Ctx) Deadline() (deadline time.Time, ok bool) {
+	return rc.Request.Context().Deadline()
+}
+
+// IsClientGone returns if the request context was cancelled, typically because
+// the client closed the connection before the response was written.
+//
+// It does not return true if the request context exceeded its deadline.
+func (rc *Ctx) IsClientGone() bool {
+	return rc.Request.Context().Err() == context.Canceled
+}
+
 // WithStateValue sets the state for a key to an object.
 func (rc *Ctx) WithStateValue(key string, value interface{}) *Ctx {
 	rc.State.Set(key, value)
@@ -189,6 +214,87 @@ func (rc *Ctx) RouteParam(key string) (output string, err error) {
 	return
 }
 
+// RouteParamOrDefault returns a string route parameter, or a default
+// if the parameter is not set or is empty.
+func (rc *Ctx) RouteParamOrDefault(key, defaultValue string) string {
+	if value := rc.RouteParams.Get(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// RouteParamIntOrDefault returns a route parameter parsed as an int, or a default
+// if the parameter is not set, is empty, or cannot be parsed.
+func (rc *Ctx) RouteParamIntOrDefault(key string, defaultValue int) int {
+	if value, err := rc.RouteParamInt(key); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// RouteParamInt returns a route parameter parsed as an int.
+//
+// It returns a parameter missing error if the parameter is not set, and a
+// parameter invalid error if the parameter cannot be parsed.
+func (rc *Ctx) RouteParamInt(key string) (int, error) {
+	value, err := rc.RouteParam(key)
+	if err != nil {
+		return 0, err
+	}
+	output, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, NewParameterInvalidError(key, "must be an integer")
+	}
+	return output, nil
+}
+
+// RouteParamInt64 returns a route parameter parsed as an int64.
+//
+// It returns a parameter missing error if the parameter is not set, and a
+// parameter invalid error if the parameter cannot be parsed.
+func (rc *Ctx) RouteParamInt64(key string) (int64, error) {
+	value, err := rc.RouteParam(key)
+	if err != nil {
+		return 0, err
+	}
+	output, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, NewParameterInvalidError(key, "must be an integer")
+	}
+	return output, nil
+}
+
+// RouteParamBool returns a route parameter parsed as a bool.
+//
+// Valid values are the same as `BoolValue`, e.g. "true", "1", "yes" or "on".
+func (rc *Ctx) RouteParamBool(key string) (bool, error) {
+	value, err := rc.RouteParam(key)
+	if err != nil {
+		return false, err
+	}
+	output, err := BoolValue(value, nil)
+	if err != nil {
+		return false, NewParameterInvalidError(key, "must be a boolean")
+	}
+	return output, nil
+}
+
+// RouteParamUUID returns a route parameter parsed as a uuid.
+//
+// It returns a parameter missing error if the parameter is not set, and a
+// parameter invalid error if the parameter cannot be parsed.
+func (rc *Ctx) RouteParamUUID(key string) (uuid.UUID, error) {
+	value, err := rc.RouteParam(key)
+	if err != nil {
+		return nil, err
+	}
+	output, err := uuid.Parse(value)
+	if err != nil {
+		return nil, NewParameterInvalidError(key, "must be a uuid")
+	}
+	return output, nil
+}
+
 // QueryValue returns a query value.
 func (rc *Ctx) QueryValue(key string) (value string, err error) {
 	if value = rc.Request.URL.Query().Get(key); len(value) > 0 {
@@ -198,6 +304,29 @@ func (rc *Ctx) QueryValue(key string) (value string, err error) {
 	return
 }
 
+// QueryValueOrDefault returns a query value, or a default
+// if the value is not set or is empty.
+func (rc *Ctx) QueryValueOrDefault(key, defaultValue string) string {
+	if value, err := rc.QueryValue(key); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// QueryValueIntOrDefault returns a query value parsed as an int, or a default
+// if the value is not set, is empty, or cannot be parsed.
+func (rc *Ctx) QueryValueIntOrDefault(key string, defaultValue int) int {
+	value, err := rc.QueryValue(key)
+	if err != nil {
+		return defaultValue
+	}
+	output, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return output
+}
+
 // FormValue returns a form value.
 func (rc *Ctx) FormValue(key string) (output string, err error) {
 	if err = rc.EnsureForm(); err != nil {
@@ -270,6 +399,71 @@ func (rc *Ctx) PostBodyAsJSON(response interface{}) error {
 	return nil
 }
 
+// BindJSON reads the incoming post body (closing it) and decodes it into a given destination as json.
+//
+// If the destination implements `Validatable`, its `Validate()` method is called after decoding.
+// Empty bodies return a parameter missing error, and decoding or validation failures return
+// a parameter invalid error, so that the error can be returned as a bad request:
+//
+//	if err := r.BindJSON(&req); err != nil {
+//		return r.DefaultProvider.BadRequest(err)
+//	}
+func (rc *Ctx) BindJSON(dest interface{}, opts ...BindOption) error {
+	var options BindOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	body, err := rc.PostBody()
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return NewParameterMissingError("body")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if options.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err = decoder.Decode(dest); err != nil {
+		return ex.New(ErrParameterInvalid, ex.OptMessagef("%q: %v", "body", err), ex.OptInner(err))
+	}
+	if typed, ok := dest.(Validatable); ok {
+		if err = typed.Validate(); err != nil {
+			return ex.New(ErrParameterInvalid, ex.OptMessagef("%q: %v", "body", err), ex.OptInner(err))
+		}
+	}
+	return nil
+}
+
+// BindQuery sets the fields of a given struct pointer from the request query string.
+//
+// Fields are mapped by their `url` struct tag, and can be strings, bools, integers
+// or `time.Duration`s. Parameters that are missing or empty leave the field unset,
+// unless the field has a `default` struct tag, in which case the default is used:
+//
+//	type listArgs struct {
+//		Page  int  `url:"page" default:"1"`
+//		Limit int  `url:"limit" default:"50"`
+//		All   bool `url:"all"`
+//	}
+//
+// Values that cannot be coerced return a parameter invalid error, so that the error
+// can be returned as a bad request. If the destination implements `Validatable`,
+// its `Validate()` method is called after binding.
+func (rc *Ctx) BindQuery(dest interface{}) error {
+	if err := bindQuery(rc.Request.URL.Query(), dest); err != nil {
+		return err
+	}
+	if typed, ok := dest.(Validatable); ok {
+		if err := typed.Validate(); err != nil {
+			return ex.New(ErrParameterInvalid, ex.OptMessagef("%q: %v", "query", err), ex.OptInner(err))
+		}
+	}
+	return nil
+}
+
 // PostBodyAsXML reads the incoming post body (closing it) and marshals it to the target object as xml.
 func (rc *Ctx) PostBodyAsXML(response interface{}) error {
 	body, err := rc.PostBody()
@@ -9,12 +9,17 @@ package web
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
 	"github.com/blend/go-sdk/uuid"
 	"github.com/blend/go-sdk/webutil"
 )
@@ -106,6 +111,127 @@ func TestCtxPostBodyAsJSON(t *testing.T) {
 	assert.NotNil(err)
 }
 
+type bindJSONTest struct {
+	Name string `json:"name"`
+}
+
+func (bjt bindJSONTest) Validate() error {
+	if bjt.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	return nil
+}
+
+func TestCtxBindJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var contents bindJSONTest
+	err := MockCtx("POST", "/", OptCtxBodyBytes([]byte(`{"name":"foo","extra":true}`))).BindJSON(&contents)
+	assert.Nil(err)
+	assert.Equal("foo", contents.Name)
+
+	contents = bindJSONTest{}
+	err = MockCtx("POST", "/", OptCtxBodyBytes([]byte(`{"name":"foo","extra":true}`))).BindJSON(&contents, OptBindDisallowUnknownFields(true))
+	assert.True(IsErrParameterInvalid(err))
+	assert.True(IsErrBadRequest(err))
+
+	contents = bindJSONTest{}
+	err = MockCtx("POST", "/", OptCtxBodyBytes([]byte(`{"name":""}`))).BindJSON(&contents)
+	assert.True(IsErrParameterInvalid(err))
+	assert.Contains(ex.ErrMessage(err), "name is required")
+
+	contents = bindJSONTest{}
+	err = MockCtx("POST", "/", OptCtxBodyBytes([]byte(`{"name":`))).BindJSON(&contents)
+	assert.True(IsErrParameterInvalid(err))
+
+	contents = bindJSONTest{}
+	err = MockCtx("POST", "/").BindJSON(&contents)
+	assert.True(IsErrParameterMissing(err))
+}
+
+type bindQueryTest struct {
+	Page     int           `url:"page" default:"1"`
+	Limit    uint          `url:"limit" default:"50"`
+	All      bool          `url:"all"`
+	Search   string        `url:"q"`
+	Timeout  time.Duration `url:"timeout" default:"5s"`
+	Ignored  string        `url:"-"`
+	Untagged string
+}
+
+type bindQueryValidateTest struct {
+	Limit int `url:"limit"`
+}
+
+func (bqvt bindQueryValidateTest) Validate() error {
+	if bqvt.Limit > 100 {
+		return fmt.Errorf("limit must be at most 100")
+	}
+	return nil
+}
+
+func TestCtxBindQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	var args bindQueryTest
+	err := MockCtx("GET", "/",
+		OptCtxQueryValue("page", "2"),
+		OptCtxQueryValue("limit", "10"),
+		OptCtxQueryValue("all", "true"),
+		OptCtxQueryValue("q", "foo bar"),
+		OptCtxQueryValue("timeout", "1m"),
+		OptCtxQueryValue("Ignored", "nope"),
+		OptCtxQueryValue("Untagged", "nope"),
+	).BindQuery(&args)
+	assert.Nil(err)
+	assert.Equal(2, args.Page)
+	assert.Equal(10, args.Limit)
+	assert.True(args.All)
+	assert.Equal("foo bar", args.Search)
+	assert.Equal(time.Minute, args.Timeout)
+	assert.Empty(args.Ignored)
+	assert.Empty(args.Untagged)
+
+	args = bindQueryTest{}
+	err = MockCtx("GET", "/").BindQuery(&args)
+	assert.Nil(err)
+	assert.Equal(1, args.Page)
+	assert.Equal(50, args.Limit)
+	assert.False(args.All)
+	assert.Empty(args.Search)
+	assert.Equal(5*time.Second, args.Timeout)
+
+	args = bindQueryTest{}
+	err = MockCtx("GET", "/", OptCtxQueryValue("page", "two")).BindQuery(&args)
+	assert.True(IsErrBadRequest(err))
+	assert.Contains(ex.ErrMessage(err), "page")
+
+	err = MockCtx("GET", "/", OptCtxQueryValue("limit", "-1")).BindQuery(&args)
+	assert.True(IsErrParameterInvalid(err))
+
+	err = MockCtx("GET", "/", OptCtxQueryValue("all", "maybe")).BindQuery(&args)
+	assert.True(IsErrParameterInvalid(err))
+
+	err = MockCtx("GET", "/", OptCtxQueryValue("timeout", "soon")).BindQuery(&args)
+	assert.True(IsErrParameterInvalid(err))
+
+	var validated bindQueryValidateTest
+	err = MockCtx("GET", "/", OptCtxQueryValue("limit", "1000")).BindQuery(&validated)
+	assert.True(IsErrParameterInvalid(err))
+	assert.Contains(ex.ErrMessage(err), "limit must be at most 100")
+
+	err = MockCtx("GET", "/").BindQuery(args)
+	assert.NotNil(err)
+	assert.False(IsErrBadRequest(err))
+
+	var unsupported struct {
+		Values []string `url:"values"`
+	}
+	err = MockCtx("GET", "/", OptCtxQueryValue("values", "a")).BindQuery(&unsupported)
+	assert.NotNil(err)
+	assert.False(IsErrBadRequest(err))
+}
+
 type postXMLTest string
 
 func TestCtxPostBodyAsXML(t *testing.T) {
@@ -150,6 +276,75 @@ func TestCtxRouteParam(t *testing.T) {
 	assert.Equal("bar", value)
 }
 
+func TestCtxRouteParamTyped(t *testing.T) {
+	assert := assert.New(t)
+
+	id := uuid.V4()
+	context := MockCtx("GET", "/",
+		OptCtxRouteParamValue("int", "1234"),
+		OptCtxRouteParamValue("int64", "9223372036854775807"),
+		OptCtxRouteParamValue("bool", "yes"),
+		OptCtxRouteParamValue("uuid", id.String()),
+		OptCtxRouteParamValue("bad", "not-a-value"),
+	)
+
+	intValue, err := context.RouteParamInt("int")
+	assert.Nil(err)
+	assert.Equal(1234, intValue)
+	int64Value, err := context.RouteParamInt64("int64")
+	assert.Nil(err)
+	assert.Equal(int64(9223372036854775807), int64Value)
+	boolValue, err := context.RouteParamBool("bool")
+	assert.Nil(err)
+	assert.True(boolValue)
+	uuidValue, err := context.RouteParamUUID("uuid")
+	assert.Nil(err)
+	assert.Equal(id.String(), uuidValue.String())
+
+	_, err = context.RouteParamInt("missing")
+	assert.True(IsErrParameterMissing(err))
+
+	_, err = context.RouteParamInt("bad")
+	assert.True(IsErrParameterInvalid(err))
+	assert.True(IsErrBadRequest(err))
+	_, err = context.RouteParamInt64("bad")
+	assert.True(IsErrParameterInvalid(err))
+	_, err = context.RouteParamBool("bad")
+	assert.True(IsErrParameterInvalid(err))
+	_, err = context.RouteParamUUID("bad")
+	assert.True(IsErrParameterInvalid(err))
+}
+
+func TestCtxRouteParamOrDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	context := MockCtx("GET", "/",
+		OptCtxRouteParamValue("foo", "bar"),
+		OptCtxRouteParamValue("empty", ""),
+		OptCtxRouteParamValue("int", "1234"),
+		OptCtxRouteParamValue("bad", "not-an-int"),
+	)
+	assert.Equal("bar", context.RouteParamOrDefault("foo", "default"))
+	assert.Equal("default", context.RouteParamOrDefault("empty", "default"))
+	assert.Equal("default", context.RouteParamOrDefault("missing", "default"))
+
+	assert.Equal(1234, context.RouteParamIntOrDefault("int", 5))
+	assert.Equal(5, context.RouteParamIntOrDefault("bad", 5))
+	assert.Equal(5, context.RouteParamIntOrDefault("missing", 5))
+}
+
+func TestCtxQueryValueOrDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	context := MockCtx("GET", "/", OptCtxQueryValue("foo", "bar"), OptCtxQueryValue("int", "1234"))
+	assert.Equal("bar", context.QueryValueOrDefault("foo", "default"))
+	assert.Equal("default", context.QueryValueOrDefault("missing", "default"))
+
+	assert.Equal(1234, context.QueryValueIntOrDefault("int", 5))
+	assert.Equal(5, context.QueryValueIntOrDefault("foo", 5))
+	assert.Equal(5, context.QueryValueIntOrDefault("missing", 5))
+}
+
 func TestCtxSession(t *testing.T) {
 	assert := assert.New(t)
 
@@ -211,3 +406,56 @@ func TestCtxPostBodyAsForm(t *testing.T) {
 	assert.Equal(3.14, p.Cost)
 	assert.Empty(p.Excluded)
 }
+
+func TestCtxIsClientGone(t *testing.T) {
+	assert := assert.New(t)
+
+	r := MockCtx("GET", "/")
+	assert.False(r.IsClientGone())
+	_, ok := r.Deadline()
+	assert.False(ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.WithContext(ctx)
+	assert.False(r.IsClientGone())
+	cancel()
+	assert.True(r.IsClientGone())
+
+	deadline := time.Now().Add(-time.Second)
+	ctx, cancel = context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	r = MockCtx("GET", "/")
+	r.WithContext(ctx)
+	actualDeadline, ok := r.Deadline()
+	assert.True(ok)
+	assert.Equal(deadline, actualDeadline)
+	assert.False(r.IsClientGone())
+}
+
+func TestCtxContextClientDisconnect(t *testing.T) {
+	assert := assert.New(t)
+
+	started := make(chan struct{})
+	gone := make(chan bool, 1)
+	app := MustNew()
+	app.GET("/long", func(r *Ctx) Result {
+		close(started)
+		<-r.Context().Done()
+		gone <- r.IsClientGone()
+		return nil
+	})
+
+	server := httptest.NewServer(app)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/long", nil)
+	assert.Nil(err)
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err = http.DefaultClient.Do(req)
+	assert.NotNil(err)
+	assert.True(<-gone)
+}
@@ -23,6 +23,8 @@ const (
 	ErrParameterMissing ex.Class = "parameter is missing"
 	// ErrParameterInvalid is an error on request validation.
 	ErrParameterInvalid ex.Class = "parameter is invalid"
+	// ErrRequestBodyTooLarge is an error returned when reading a request body beyond the `MaxBodySize` limit.
+	ErrRequestBodyTooLarge ex.Class = "request body too large"
 )
 
 // NewParameterMissingError returns a new parameter missing error.
@@ -32,7 +34,7 @@ func NewParameterMissingError(paramName string) error {
 
 // NewParameterInvalidError returns a new parameter invalid error.
 func NewParameterInvalidError(paramName, message string) error {
-	return ex.New(ErrParameterMissing, ex.OptMessagef("%q: %s", paramName, message))
+	return ex.New(ErrParameterInvalid, ex.OptMessagef("%q: %s", paramName, message))
 }
 
 // IsErrSessionInvalid returns if an error is a session invalid error.
@@ -66,5 +68,13 @@ func IsErrParameterInvalid(err error) bool {
 	if err == nil {
 		return false
 	}
-	return ex.Is(err, ErrParameterMissing)
+	return ex.Is(err, ErrParameterInvalid)
+}
+
+// IsErrRequestBodyTooLarge returns if an error is an ErrRequestBodyTooLarge.
+func IsErrRequestBodyTooLarge(err error) bool {
+	if err == nil {
+		return false
+	}
+	return ex.Is(err, ErrRequestBodyTooLarge)
 }
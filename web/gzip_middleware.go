@@ -12,6 +12,9 @@ import (
 )
 
 // GZip is a middleware the implements gzip compression for requests that opt into it.
+//
+// Deprecated: use `Compression`, which also supports deflate and skips compressing
+// small, already encoded or incompressible responses.
 func GZip(action Action) Action {
 	return func(r *Ctx) Result {
 		if webutil.HeaderAny(r.Request.Header, webutil.HeaderAcceptEncoding, webutil.ContentEncodingGZIP) {
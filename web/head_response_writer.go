@@ -0,0 +1,73 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+var (
+	_ http.ResponseWriter = (*headResponseWriter)(nil)
+	_ http.Flusher        = (*headResponseWriter)(nil)
+)
+
+// headResponseWriter serves a `HEAD` request with a `GET` handler.
+//
+// It discards the body, and holds the status until the handler completes so that
+// it can set the `Content-Length` the `GET` response would have had.
+type headResponseWriter struct {
+	http.ResponseWriter
+
+	statusCode    int
+	contentLength int
+	wroteHeader   bool
+}
+
+// WriteHeader records the status code; it is written when the handler completes.
+func (hw *headResponseWriter) WriteHeader(statusCode int) {
+	if hw.statusCode == 0 {
+		hw.statusCode = statusCode
+	}
+}
+
+// Write counts but discards the body.
+func (hw *headResponseWriter) Write(contents []byte) (int, error) {
+	if hw.statusCode == 0 {
+		hw.statusCode = http.StatusOK
+	}
+	hw.contentLength += len(contents)
+	return len(contents), nil
+}
+
+// Flush writes the header and flushes the underlying writer if it supports it.
+func (hw *headResponseWriter) Flush() {
+	hw.writeHeader()
+	if typed, ok := hw.ResponseWriter.(http.Flusher); ok {
+		typed.Flush()
+	}
+}
+
+// writeHeader writes the recorded status code, setting the
+// `Content-Length` from the discarded body if the handler didn't set it.
+func (hw *headResponseWriter) writeHeader() {
+	if hw.wroteHeader {
+		return
+	}
+	hw.wroteHeader = true
+	if hw.statusCode == 0 {
+		hw.statusCode = http.StatusOK
+	}
+	header := hw.Header()
+	if header.Get(webutil.HeaderContentLength) == "" && hw.contentLength > 0 && webutil.BodyAllowedForStatus(hw.statusCode) {
+		header.Set(webutil.HeaderContentLength, strconv.Itoa(hw.contentLength))
+	}
+	hw.ResponseWriter.WriteHeader(hw.statusCode)
+}
@@ -0,0 +1,58 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// JSONAction is an action that returns a value to render as json, or an error.
+type JSONAction func(*Ctx) (interface{}, error)
+
+// JSONErrorResponse is the json body rendered for errors returned by a `JSONAction`.
+type JSONErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+// Action returns the json action as an action that can be registered with an app:
+//
+//	app.GET("/users/:id", web.JSONAction(func(r *web.Ctx) (interface{}, error) {
+//		id, err := r.RouteParamInt("id")
+//		if err != nil {
+//			return nil, err
+//		}
+//		return users.Get(r.Context(), id)
+//	}).Action())
+//
+// Values are rendered as json with a `200`, unless the value is itself a `Result`, which is
+// returned as is. Bad request errors (see `IsErrBadRequest`) are rendered as a `400` with the
+// error class and message. Any other error is logged and rendered as a `500` with only the
+// status text, so that internal details are not returned to the client.
+func (ja JSONAction) Action() Action {
+	return func(r *Ctx) Result {
+		value, err := ja(r)
+		if err != nil {
+			if IsErrBadRequest(err) {
+				return JSON.Status(http.StatusBadRequest, JSONErrorResponse{
+					Error:   ex.ErrClass(err).Error(),
+					Message: ex.ErrMessage(err),
+				})
+			}
+			return ResultWithLoggedError(JSON.Status(http.StatusInternalServerError, JSONErrorResponse{
+				Error: http.StatusText(http.StatusInternalServerError),
+			}), err)
+		}
+		if typed, ok := value.(Result); ok {
+			return typed
+		}
+		return JSON.Result(value)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestJSONAction(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/ok/:id", JSONAction(func(r *Ctx) (interface{}, error) {
+		id, err := r.RouteParamInt("id")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]int{"id": id}, nil
+	}).Action())
+	app.GET("/error", JSONAction(func(_ *Ctx) (interface{}, error) {
+		return nil, ex.New("database is down", ex.OptMessage("connection refused at 10.0.0.1"))
+	}).Action())
+	app.GET("/plain-error", JSONAction(func(_ *Ctx) (interface{}, error) {
+		return nil, fmt.Errorf("plain error")
+	}).Action())
+	app.GET("/result", JSONAction(func(_ *Ctx) (interface{}, error) {
+		return NoContent, nil
+	}).Action())
+
+	var ok map[string]int
+	meta, err := MockGet(app, "/ok/1234").JSON(&ok)
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal(webutil.ContentTypeApplicationJSON, meta.Header.Get(webutil.HeaderContentType))
+	assert.Equal(1234, ok["id"])
+
+	var badRequest JSONErrorResponse
+	meta, err = MockGet(app, "/ok/foo").JSON(&badRequest)
+	assert.Nil(err)
+	assert.Equal(http.StatusBadRequest, meta.StatusCode)
+	assert.Equal(string(ErrParameterInvalid), badRequest.Error)
+	assert.Equal(`"id": must be an integer`, badRequest.Message)
+
+	var internalError JSONErrorResponse
+	meta, err = MockGet(app, "/error").JSON(&internalError)
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, meta.StatusCode)
+	assert.Equal(http.StatusText(http.StatusInternalServerError), internalError.Error)
+	assert.Empty(internalError.Message)
+
+	internalError = JSONErrorResponse{}
+	meta, err = MockGet(app, "/plain-error").JSON(&internalError)
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, meta.StatusCode)
+	assert.Equal(http.StatusText(http.StatusInternalServerError), internalError.Error)
+
+	meta, err = MockGet(app, "/result").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNoContent, meta.StatusCode)
+}
+
+func TestJSONActionLogsInternalErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	result := JSONAction(func(_ *Ctx) (interface{}, error) {
+		return nil, fmt.Errorf("only a test")
+	}).Action()(MockCtx(http.MethodGet, "/"))
+	typed, ok := result.(*LoggedErrorResult)
+	assert.True(ok)
+	assert.Equal("only a test", typed.Error.Error())
+
+	result = JSONAction(func(_ *Ctx) (interface{}, error) {
+		return nil, NewParameterMissingError("id")
+	}).Action()(MockCtx(http.MethodGet, "/"))
+	_, ok = result.(*LoggedErrorResult)
+	assert.False(ok)
+}
@@ -75,9 +75,13 @@ func (jwtm JWTManager) FetchHandler(_ context.Context, sessionValue string) (*Se
 
 // Claims returns the sesion as a JWT standard claims object.
 func (jwtm JWTManager) Claims(session *Session) *jwt.StandardClaims {
+	var audience jwt.Audience
+	if session.BaseURL != "" {
+		audience = jwt.Audience{session.BaseURL}
+	}
 	return &jwt.StandardClaims{
 		ID:        session.SessionID,
-		Audience:  session.BaseURL,
+		Audience:  audience,
 		Issuer:    "go-web",
 		Subject:   session.UserID,
 		IssuedAt:  session.CreatedUTC.Unix(),
@@ -87,9 +91,13 @@ func (jwtm JWTManager) Claims(session *Session) *jwt.StandardClaims {
 
 // FromClaims returns a session from a given claims set.
 func (jwtm JWTManager) FromClaims(claims *jwt.StandardClaims) *Session {
+	var baseURL string
+	if len(claims.Audience) > 0 {
+		baseURL = claims.Audience[0]
+	}
 	return &Session{
 		SessionID:  claims.ID,
-		BaseURL:    claims.Audience,
+		BaseURL:    baseURL,
 		UserID:     claims.Subject,
 		CreatedUTC: time.Unix(claims.IssuedAt, 0).In(time.UTC),
 		ExpiresUTC: time.Unix(claims.ExpiresAt, 0).In(time.UTC),
@@ -47,7 +47,7 @@ func TestNewJWTManagerClaims(t *testing.T) {
 
 	claims := m.Claims(session)
 	assert.Equal(session.SessionID, claims.ID)
-	assert.Equal(session.BaseURL, claims.Audience)
+	assert.Equal(jwt.Audience{session.BaseURL}, claims.Audience)
 	assert.Equal("go-web", claims.Issuer)
 	assert.Equal(session.UserID, claims.Subject)
 	assert.Equal(session.CreatedUTC, time.Unix(claims.IssuedAt, 0).In(time.UTC))
@@ -62,7 +62,7 @@ func TestNewJWTManagerFromClaims(t *testing.T) {
 
 	claims := &jwt.StandardClaims{
 		ID:        uuid.V4().String(),
-		Audience:  uuid.V4().String(),
+		Audience:  jwt.Audience{uuid.V4().String()},
 		Issuer:    "go-web",
 		Subject:   uuid.V4().String(),
 		IssuedAt:  time.Date(2018, 9, 8, 12, 00, 0, 0, time.UTC).Unix(),
@@ -71,7 +71,7 @@ func TestNewJWTManagerFromClaims(t *testing.T) {
 
 	session := m.FromClaims(claims)
 	assert.Equal(session.SessionID, claims.ID)
-	assert.Equal(session.BaseURL, claims.Audience)
+	assert.Equal(jwt.Audience{session.BaseURL}, claims.Audience)
 	assert.Equal(session.UserID, claims.Subject)
 	assert.Equal(session.CreatedUTC, time.Unix(claims.IssuedAt, 0).In(time.UTC))
 	assert.Equal(session.ExpiresUTC, time.Unix(claims.ExpiresAt, 0).In(time.UTC))
@@ -91,7 +91,7 @@ func TestNewJWTManagerKeyFunc(t *testing.T) {
 
 	claims := &jwt.StandardClaims{
 		ID:        uuid.V4().String(),
-		Audience:  uuid.V4().String(),
+		Audience:  jwt.Audience{uuid.V4().String()},
 		Issuer:    "go-web",
 		Subject:   uuid.V4().String(),
 		IssuedAt:  time.Date(2018, 9, 8, 12, 00, 0, 0, time.UTC).Unix(),
@@ -0,0 +1,87 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/blend/go-sdk/logger"
+)
+
+// StatusClientClosedRequest is the non-standard status logged for requests
+// whose client went away before the response was finished.
+const StatusClientClosedRequest = 499
+
+// stateKeyRequestLogged marks a request as logged by the `Logged` middleware.
+const stateKeyRequestLogged = "web.request_logged"
+
+// Logged returns a middleware that logs a `webutil.HTTPRequestEvent` for each request
+// once its result has rendered, with the method, path, status, elapsed time, bytes written
+// and remote address.
+//
+// Requests are logged once, so the app does not also log requests that go through the
+// middleware. If the action panics, the request is logged with a `500` before the panic
+// continues to any outer `Recover` middleware; use `Recover` inside `Logged` to log the
+// rendered recovery result instead. Requests whose client went away before the response
+// was finished are logged with `StatusClientClosedRequest`.
+func Logged(log logger.Log) Middleware {
+	return func(action Action) Action {
+		return func(r *Ctx) (result Result) {
+			r.WithStateValue(stateKeyRequestLogged, true)
+			defer func() {
+				if rcv := recover(); rcv != nil {
+					logRequest(log, r, http.StatusInternalServerError)
+					panic(rcv)
+				}
+			}()
+			result = action(r)
+			if result == nil {
+				logRequest(log, r, r.Response.StatusCode())
+				return nil
+			}
+			return &loggedResult{Result: result, log: log}
+		}
+	}
+}
+
+// logRequest triggers a request event with a given status code.
+func logRequest(log logger.Log, r *Ctx, statusCode int) {
+	if r.IsClientGone() {
+		statusCode = StatusClientClosedRequest
+	}
+	logger.MaybeTriggerContext(r.Context(), log, newHTTPRequestEvent(r, statusCode))
+}
+
+var (
+	_ Result           = (*loggedResult)(nil)
+	_ ResultPreRender  = (*loggedResult)(nil)
+	_ ResultPostRender = (*loggedResult)(nil)
+)
+
+// loggedResult logs the request after a result has rendered.
+type loggedResult struct {
+	Result
+	log logger.Log
+}
+
+// PreRender calls the pre render step of the result if it has one.
+func (lr *loggedResult) PreRender(ctx *Ctx) error {
+	if typed, ok := lr.Result.(ResultPreRender); ok {
+		return typed.PreRender(ctx)
+	}
+	return nil
+}
+
+// PostRender calls the post render step of the result if it has one, then logs the request.
+func (lr *loggedResult) PostRender(ctx *Ctx) (err error) {
+	if typed, ok := lr.Result.(ResultPostRender); ok {
+		err = typed.PostRender(ctx)
+	}
+	logRequest(lr.log, ctx, ctx.Response.StatusCode())
+	return
+}
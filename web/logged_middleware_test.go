@@ -0,0 +1,135 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/logger"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+type loggedRequests struct {
+	sync.Mutex
+	events []webutil.HTTPRequestEvent
+}
+
+func (lr *loggedRequests) listen(log *logger.Logger) {
+	log.Listen(webutil.FlagHTTPRequest, "test", webutil.NewHTTPRequestEventListener(func(_ context.Context, e webutil.HTTPRequestEvent) {
+		lr.Lock()
+		defer lr.Unlock()
+		lr.events = append(lr.events, e)
+	}))
+}
+
+func TestLogged(t *testing.T) {
+	assert := assert.New(t)
+
+	log := logger.Memory(new(bytes.Buffer), logger.OptEnabled(webutil.FlagHTTPRequest))
+	defer log.Close()
+	var logged loggedRequests
+	logged.listen(log)
+
+	// the app logs requests as well, which should not double count.
+	app := MustNew(OptLog(log))
+	app.GET("/users/:id", func(_ *Ctx) Result {
+		return Text.Result("this is a test")
+	}, Logged(log))
+	app.GET("/missing", func(r *Ctx) Result {
+		return Text.NotFound()
+	}, Logged(log))
+	app.GET("/unlogged", func(r *Ctx) Result {
+		return Text.OK()
+	})
+
+	_, err := MockGet(app, "/users/1234", r2.OptHeaderValue(webutil.HeaderXForwardedFor, "10.0.0.1")).Discard()
+	assert.Nil(err)
+	_, err = MockGet(app, "/missing").Discard()
+	assert.Nil(err)
+	_, err = MockGet(app, "/unlogged").Discard()
+	assert.Nil(err)
+	log.Drain()
+
+	logged.Lock()
+	defer logged.Unlock()
+	assert.Len(logged.events, 3)
+
+	event := logged.events[0]
+	assert.Equal(http.MethodGet, event.Request.Method)
+	assert.Equal("/users/1234", event.Request.URL.Path)
+	assert.Equal("/users/:id", event.Route)
+	assert.Equal(http.StatusOK, event.StatusCode)
+	assert.Equal(len("this is a test"), event.ContentLength)
+	assert.Equal("10.0.0.1", webutil.GetRemoteAddr(event.Request))
+	assert.NotZero(event.Elapsed)
+
+	assert.Equal(http.StatusNotFound, logged.events[1].StatusCode)
+	assert.Equal("/unlogged", logged.events[2].Request.URL.Path)
+}
+
+func TestLoggedPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	log := logger.Memory(new(bytes.Buffer), logger.OptEnabled(webutil.FlagHTTPRequest))
+	defer log.Close()
+	var logged loggedRequests
+	logged.listen(log)
+
+	app := MustNew(OptConfig(Config{DisablePanicRecovery: true}), OptLog(log))
+	app.GET("/panic", func(_ *Ctx) Result {
+		panic("this is only a test")
+	}, Logged(log), Recover(nil))
+	app.GET("/recovered", func(_ *Ctx) Result {
+		panic("this is only a test")
+	}, Recover(nil), Logged(log))
+
+	meta, err := MockGet(app, "/panic").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, meta.StatusCode)
+	meta, err = MockGet(app, "/recovered").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, meta.StatusCode)
+	log.Drain()
+
+	logged.Lock()
+	defer logged.Unlock()
+	assert.Len(logged.events, 2)
+	assert.Equal(http.StatusInternalServerError, logged.events[0].StatusCode)
+	assert.Equal(http.StatusInternalServerError, logged.events[1].StatusCode)
+}
+
+func TestLoggedClientGone(t *testing.T) {
+	assert := assert.New(t)
+
+	log := logger.Memory(new(bytes.Buffer), logger.OptEnabled(webutil.FlagHTTPRequest))
+	defer log.Close()
+	var logged loggedRequests
+	logged.listen(log)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := MockCtx(http.MethodGet, "/")
+	r.WithContext(ctx)
+
+	result := Logged(log)(func(_ *Ctx) Result {
+		cancel()
+		return nil
+	})(r)
+	assert.Nil(result)
+	log.Drain()
+
+	logged.Lock()
+	defer logged.Unlock()
+	assert.Len(logged.events, 1)
+	assert.Equal(StatusClientClosedRequest, logged.events[0].StatusCode)
+}
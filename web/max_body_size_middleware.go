@@ -0,0 +1,82 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/fileutil"
+)
+
+// MaxBodySize returns a middleware that limits request bodies to a given number of bytes.
+//
+// The request body is wrapped with `http.MaxBytesReader`, so reads beyond the limit
+// fail with an `ErrRequestBodyTooLarge` error. If the limit is tripped while the action
+// reads the body (e.g. with `PostBodyAsJSON`), a `413` result is returned in place of
+// the action's result. Requests with a declared content length over the limit are
+// rejected before the action is called.
+func MaxBodySize(maxBytes int64) Middleware {
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			if r.Request.ContentLength > maxBytes {
+				return requestBodyTooLarge(r)
+			}
+			if r.Request.Body == nil || r.Request.Body == http.NoBody {
+				return action(r)
+			}
+			body := &maxBodySizeReader{
+				ReadCloser: http.MaxBytesReader(r.Response, r.Request.Body, maxBytes),
+				maxBytes:   maxBytes,
+			}
+			r.Request.Body = body
+			result := action(r)
+			if body.exceeded {
+				return requestBodyTooLarge(r)
+			}
+			return result
+		}
+	}
+}
+
+// MaxBodySizeFromString returns a middleware that limits request bodies to a given
+// file size string (e.g. `10mb`) as parsed by `fileutil.ParseFileSize`.
+func MaxBodySizeFromString(maxSize string) (Middleware, error) {
+	maxBytes, err := fileutil.ParseFileSize(maxSize)
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	return MaxBodySize(maxBytes), nil
+}
+
+func requestBodyTooLarge(r *Ctx) Result {
+	if r.DefaultProvider != nil {
+		return r.DefaultProvider.Status(http.StatusRequestEntityTooLarge, nil)
+	}
+	return Text.Status(http.StatusRequestEntityTooLarge, nil)
+}
+
+// maxBodySizeReader records if a max bytes reader tripped its limit.
+type maxBodySizeReader struct {
+	io.ReadCloser
+	maxBytes int64
+	read     int64
+	exceeded bool
+}
+
+// Read reads from the limited body.
+func (mbr *maxBodySizeReader) Read(p []byte) (int, error) {
+	n, err := mbr.ReadCloser.Read(p)
+	mbr.read += int64(n)
+	if err != nil && err != io.EOF && mbr.read >= mbr.maxBytes {
+		mbr.exceeded = true
+		return n, ex.New(ErrRequestBodyTooLarge, ex.OptMessagef("max bytes: %d", mbr.maxBytes), ex.OptInner(err))
+	}
+	return n, err
+}
@@ -0,0 +1,98 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/fileutil"
+)
+
+func maxBodySizeEcho(r *Ctx) Result {
+	var body map[string]string
+	if err := r.PostBodyAsJSON(&body); err != nil {
+		return JSON.BadRequest(err)
+	}
+	return JSON.Result(body)
+}
+
+func TestMaxBodySize(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.POST("/", maxBodySizeEcho, MaxBodySize(64))
+
+	body, meta, err := MockPost(app, "/", ioutil.NopCloser(strings.NewReader(`{"foo":"bar"}`))).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal("{\"foo\":\"bar\"}\n", string(body))
+
+	large := `{"foo":"` + strings.Repeat("a", 128) + `"}`
+	meta, err = MockPost(app, "/", ioutil.NopCloser(strings.NewReader(large))).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusRequestEntityTooLarge, meta.StatusCode)
+}
+
+func TestMaxBodySizeContentLength(t *testing.T) {
+	assert := assert.New(t)
+
+	r := MockCtx(http.MethodPost, "/")
+	r.Request.ContentLength = 128
+	r.Request.Body = ioutil.NopCloser(strings.NewReader(strings.Repeat("a", 128)))
+
+	var called bool
+	result := MaxBodySize(64)(func(_ *Ctx) Result {
+		called = true
+		return Text.OK()
+	})(r)
+	assert.False(called)
+	typed, ok := result.(*RawResult)
+	assert.True(ok)
+	assert.Equal(http.StatusRequestEntityTooLarge, typed.StatusCode)
+}
+
+func TestMaxBodySizeReader(t *testing.T) {
+	assert := assert.New(t)
+
+	r := MockCtx(http.MethodPost, "/")
+	r.Request.Body = ioutil.NopCloser(strings.NewReader(strings.Repeat("a", 128)))
+
+	var readErr error
+	result := MaxBodySize(64)(func(r *Ctx) Result {
+		_, readErr = r.PostBody()
+		return Text.OK()
+	})(r)
+	assert.True(IsErrRequestBodyTooLarge(readErr))
+	typed, ok := result.(*RawResult)
+	assert.True(ok)
+	assert.Equal(http.StatusRequestEntityTooLarge, typed.StatusCode)
+}
+
+func TestMaxBodySizeFromString(t *testing.T) {
+	assert := assert.New(t)
+
+	middleware, err := MaxBodySizeFromString("10kb")
+	assert.Nil(err)
+	assert.NotNil(middleware)
+
+	r := MockCtx(http.MethodPost, "/")
+	r.Request.Body = ioutil.NopCloser(strings.NewReader(strings.Repeat("a", int(10*fileutil.Kilobyte)+1)))
+	var readErr error
+	_ = middleware(func(r *Ctx) Result {
+		_, readErr = r.PostBody()
+		return nil
+	})(r)
+	assert.True(IsErrRequestBodyTooLarge(readErr))
+
+	_, err = MaxBodySizeFromString("not a size")
+	assert.NotNil(err)
+}
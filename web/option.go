@@ -205,6 +205,18 @@ func OptMethodNotAllowedHandler(action Action) Option {
 	}
 }
 
+// OptOptionsHandler sets the handler for automatic `OPTIONS` responses for registered paths.
+//
+// This is useful for responding to CORS preflight requests:
+//
+//	web.OptOptionsHandler(web.CORS(opts...)(func(_ *web.Ctx) web.Result { return web.NoContent }))
+func OptOptionsHandler(action Action) Option {
+	return func(a *App) error {
+		a.OptionsHandler = a.RenderAction(action)
+		return nil
+	}
+}
+
 // OptNotFoundHandler sets default headers.
 func OptNotFoundHandler(action Action) Option {
 	return func(a *App) error {
@@ -213,6 +225,37 @@ func OptNotFoundHandler(action Action) Option {
 	}
 }
 
+// OptRedirectTrailingSlash sets if requests that only match a route when a trailing
+// slash is added or removed should be redirected to that route.
+//
+// It is enabled by default.
+func OptRedirectTrailingSlash(redirectTrailingSlash bool) Option {
+	return func(a *App) error {
+		a.SkipTrailingSlashRedirects = !redirectTrailingSlash
+		return nil
+	}
+}
+
+// OptAutoHead sets if `HEAD` requests that do not match a `HEAD` route should be
+// served by the `GET` route for the same path, with the body discarded.
+//
+// It is enabled by default.
+func OptAutoHead(autoHead bool) Option {
+	return func(a *App) error {
+		a.SkipAutoHead = !autoHead
+		return nil
+	}
+}
+
+// OptRedirectFixedPath sets if requests that do not match a route should be
+// redirected to a route that matches case-insensitively after cleaning the path.
+func OptRedirectFixedPath(redirectFixedPath bool) Option {
+	return func(a *App) error {
+		a.RedirectFixedPath = redirectFixedPath
+		return nil
+	}
+}
+
 // OptShutdownGracePeriod sets the shutdown grace period.
 func OptShutdownGracePeriod(d time.Duration) Option {
 	return func(a *App) error {
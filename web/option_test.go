@@ -168,3 +168,33 @@ func TestOptBaseURL(t *testing.T) {
 	assert.Nil(OptBaseURL("https://example.local")(&app))
 	assert.Equal("https://example.local", app.Config.BaseURL)
 }
+
+func TestOptRedirectFixedPath(t *testing.T) {
+	assert := assert.New(t)
+
+	app := App{RouteTree: new(RouteTree)}
+	assert.False(app.RedirectFixedPath)
+	assert.Nil(OptRedirectFixedPath(true)(&app))
+	assert.True(app.RedirectFixedPath)
+}
+
+func TestOptAutoHead(t *testing.T) {
+	assert := assert.New(t)
+
+	app := App{RouteTree: new(RouteTree)}
+	assert.False(app.SkipAutoHead)
+	assert.Nil(OptAutoHead(false)(&app))
+	assert.True(app.SkipAutoHead)
+	assert.Nil(OptAutoHead(true)(&app))
+	assert.False(app.SkipAutoHead)
+}
+
+func TestOptRedirectTrailingSlash(t *testing.T) {
+	assert := assert.New(t)
+
+	app := App{RouteTree: new(RouteTree)}
+	assert.Nil(OptRedirectTrailingSlash(false)(&app))
+	assert.True(app.SkipTrailingSlashRedirects)
+	assert.Nil(OptRedirectTrailingSlash(true)(&app))
+	assert.False(app.SkipTrailingSlashRedirects)
+}
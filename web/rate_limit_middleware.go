@@ -0,0 +1,154 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+// DefaultRateLimitIdleTimeout is the default time a client's bucket is kept after its last request.
+const DefaultRateLimitIdleTimeout = time.Minute
+
+// RateLimitOptions are options for the rate limit middleware.
+type RateLimitOptions struct {
+	// KeyFunc returns the key requests are limited by; it defaults to the client address.
+	KeyFunc func(*Ctx) string
+	// IdleTimeout is how long a bucket is kept after its last request.
+	// It is never less than the time it takes an empty bucket to refill.
+	IdleTimeout time.Duration
+}
+
+// RateLimitOption mutates rate limit options.
+type RateLimitOption func(*RateLimitOptions)
+
+// OptRateLimitKeyFunc sets the function that returns the key requests are limited by,
+// e.g. to limit by api key or user id instead of by client address.
+func OptRateLimitKeyFunc(keyFunc func(*Ctx) string) RateLimitOption {
+	return func(rlo *RateLimitOptions) { rlo.KeyFunc = keyFunc }
+}
+
+// OptRateLimitIdleTimeout sets how long a bucket is kept after its last request.
+func OptRateLimitIdleTimeout(idleTimeout time.Duration) RateLimitOption {
+	return func(rlo *RateLimitOptions) { rlo.IdleTimeout = idleTimeout }
+}
+
+// RateLimit returns a middleware that limits requests with a token bucket per client.
+//
+// Each client can make `burst` requests at once, refilled at `rps` requests per second.
+// Clients are keyed by `webutil.GetRemoteAddr` unless `OptRateLimitKeyFunc` is set.
+// Requests over the limit get a `429` result with a `Retry-After` header and the action is not called.
+// Buckets that have been idle for the idle timeout are removed, as they would be full anyway.
+func RateLimit(rps float64, burst int, opts ...RateLimitOption) Middleware {
+	options := RateLimitOptions{
+		KeyFunc:     rateLimitRemoteAddr,
+		IdleTimeout: DefaultRateLimitIdleTimeout,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	limiter := newRateLimiter(rps, burst, options.IdleTimeout)
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			allowed, retryAfter := limiter.allow(options.KeyFunc(r))
+			if allowed {
+				return action(r)
+			}
+			r.Response.Header().Set(webutil.HeaderRetryAfter, strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+			if r.DefaultProvider != nil {
+				return r.DefaultProvider.Status(http.StatusTooManyRequests, nil)
+			}
+			return Text.Status(http.StatusTooManyRequests, nil)
+		}
+	}
+}
+
+func rateLimitRemoteAddr(r *Ctx) string {
+	return webutil.GetRemoteAddr(r.Request)
+}
+
+func newRateLimiter(rps float64, burst int, idleTimeout time.Duration) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	// removing a bucket before it would have refilled would reset it early.
+	if rps > 0 {
+		if refill := time.Duration(float64(burst) / rps * float64(time.Second)); refill > idleTimeout {
+			idleTimeout = refill
+		}
+	}
+	return &rateLimiter{
+		rps:         rps,
+		burst:       float64(burst),
+		idleTimeout: idleTimeout,
+		buckets:     make(map[string]*rateLimitBucket),
+		now:         time.Now,
+	}
+}
+
+// rateLimiter is a set of token buckets by key.
+type rateLimiter struct {
+	sync.Mutex
+	rps         float64
+	burst       float64
+	idleTimeout time.Duration
+	buckets     map[string]*rateLimitBucket
+	lastSweep   time.Time
+	now         func() time.Time
+}
+
+type rateLimitBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// allow takes a token from the bucket for a given key, returning if there was one
+// and otherwise how long until there is.
+func (rl *rateLimiter) allow(key string) (allowed bool, retryAfter time.Duration) {
+	rl.Lock()
+	defer rl.Unlock()
+
+	now := rl.now()
+	rl.sweep(now)
+
+	bucket, ok := rl.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{tokens: rl.burst}
+		rl.buckets[key] = bucket
+	} else if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens = math.Min(rl.burst, bucket.tokens+elapsed.Seconds()*rl.rps)
+	}
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	if rl.rps <= 0 {
+		return false, rl.idleTimeout
+	}
+	return false, time.Duration((1 - bucket.tokens) / rl.rps * float64(time.Second))
+}
+
+// sweep removes idle buckets, at most once per idle timeout.
+func (rl *rateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < rl.idleTimeout {
+		return
+	}
+	rl.lastSweep = now
+	for key, bucket := range rl.buckets {
+		if now.Sub(bucket.last) >= rl.idleTimeout {
+			delete(rl.buckets, key)
+		}
+	}
+}
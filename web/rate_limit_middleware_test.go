@@ -0,0 +1,137 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/", func(_ *Ctx) Result { return Text.OK() }, RateLimit(1, 2))
+
+	for x := 0; x < 2; x++ {
+		meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderXForwardedFor, "10.0.0.1")).Discard()
+		assert.Nil(err)
+		assert.Equal(http.StatusOK, meta.StatusCode)
+	}
+
+	meta, err := MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderXForwardedFor, "10.0.0.1")).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusTooManyRequests, meta.StatusCode)
+	assert.Equal("1", meta.Header.Get(webutil.HeaderRetryAfter))
+
+	// other clients have their own bucket.
+	meta, err = MockGet(app, "/", r2.OptHeaderValue(webutil.HeaderXForwardedFor, "10.0.0.2")).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+}
+
+func TestRateLimitKeyFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	action := RateLimit(1, 1, OptRateLimitKeyFunc(func(r *Ctx) string {
+		return r.Request.Header.Get("X-Api-Key")
+	}))(func(_ *Ctx) Result {
+		calls++
+		return Text.OK()
+	})
+
+	action(MockCtx(http.MethodGet, "/", OptCtxHeaderValue("X-Api-Key", "foo")))
+	action(MockCtx(http.MethodGet, "/", OptCtxHeaderValue("X-Api-Key", "bar")))
+	assert.Equal(2, calls)
+
+	r := MockCtx(http.MethodGet, "/", OptCtxHeaderValue("X-Api-Key", "foo"))
+	result := action(r)
+	assert.Equal(2, calls)
+	typed, ok := result.(*RawResult)
+	assert.True(ok)
+	assert.Equal(http.StatusTooManyRequests, typed.StatusCode)
+	assert.Equal("1", r.Response.Header().Get(webutil.HeaderRetryAfter))
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 01, 01, 12, 00, 00, 00, time.UTC)
+	limiter := newRateLimiter(2, 2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	allowed, _ := limiter.allow("foo")
+	assert.True(allowed)
+	allowed, _ = limiter.allow("foo")
+	assert.True(allowed)
+	allowed, retryAfter := limiter.allow("foo")
+	assert.False(allowed)
+	assert.Equal(500*time.Millisecond, retryAfter)
+
+	now = now.Add(250 * time.Millisecond)
+	allowed, retryAfter = limiter.allow("foo")
+	assert.False(allowed)
+	assert.Equal(250*time.Millisecond, retryAfter)
+
+	now = now.Add(250 * time.Millisecond)
+	allowed, _ = limiter.allow("foo")
+	assert.True(allowed)
+
+	// the bucket never refills beyond the burst.
+	now = now.Add(time.Hour)
+	allowed, _ = limiter.allow("foo")
+	assert.True(allowed)
+	allowed, _ = limiter.allow("foo")
+	assert.True(allowed)
+	allowed, _ = limiter.allow("foo")
+	assert.False(allowed)
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 01, 01, 12, 00, 00, 00, time.UTC)
+	limiter := newRateLimiter(10, 1, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	limiter.allow("foo")
+	limiter.allow("bar")
+	assert.Len(limiter.buckets, 2)
+
+	now = now.Add(30 * time.Second)
+	limiter.allow("bar")
+	assert.Len(limiter.buckets, 2)
+
+	now = now.Add(45 * time.Second)
+	limiter.allow("baz")
+	assert.Len(limiter.buckets, 2)
+	_, ok := limiter.buckets["foo"]
+	assert.False(ok)
+}
+
+func TestRateLimiterIdleTimeoutRefill(t *testing.T) {
+	assert := assert.New(t)
+
+	// buckets are kept at least until they would have refilled.
+	limiter := newRateLimiter(1, 120, time.Minute)
+	assert.Equal(2*time.Minute, limiter.idleTimeout)
+
+	limiter = newRateLimiter(0, 0, time.Minute)
+	assert.Equal(time.Minute, limiter.idleTimeout)
+	allowed, _ := limiter.allow("foo")
+	assert.True(allowed)
+	allowed, retryAfter := limiter.allow("foo")
+	assert.False(allowed)
+	assert.Equal(time.Minute, retryAfter)
+}
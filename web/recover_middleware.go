@@ -0,0 +1,48 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/logger"
+)
+
+// Recover is a middleware that recovers panics in the action, logs them
+// as fatal errors and returns an internal server error result.
+//
+// The recovered value is wrapped with `ex.New` so the logged error
+// includes the stack of the panic. Panics with `http.ErrAbortHandler`
+// are re-raised so the server can abort the response.
+func Recover(log logger.Log) Middleware {
+	return func(action Action) Action {
+		return func(r *Ctx) (result Result) {
+			defer func() {
+				if rcv := recover(); rcv != nil {
+					if rcv == http.ErrAbortHandler {
+						panic(rcv)
+					}
+					err := ex.New(rcv)
+					logger.MaybeTriggerContext(r.Context(), log, logger.NewErrorEvent(
+						logger.Fatal,
+						err,
+						logger.OptErrorEventState(r.Request),
+					))
+					if r.DefaultProvider != nil {
+						result = r.DefaultProvider.InternalError(err)
+						return
+					}
+					result = Text.InternalError(err)
+				}
+			}()
+			result = action(r)
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/logger"
+)
+
+func TestRecover(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := new(bytes.Buffer)
+	log := logger.Memory(buffer, logger.OptEnabled(logger.Fatal))
+	defer log.Close()
+
+	app := MustNew(OptConfig(Config{DisablePanicRecovery: true}))
+	app.GET("/panic", func(_ *Ctx) Result {
+		panic("this is only a test")
+	}, Recover(log))
+	app.GET("/ok", func(_ *Ctx) Result {
+		return Text.Result("OK!")
+	}, Recover(log))
+
+	_, meta, err := MockGet(app, "/panic").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, meta.StatusCode)
+	log.Drain()
+	assert.True(strings.Contains(buffer.String(), "this is only a test"), buffer.String())
+
+	contents, meta, err := MockGet(app, "/ok").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal("OK!", string(contents))
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	action := Recover(nil)(func(_ *Ctx) Result {
+		panic(http.ErrAbortHandler)
+	})
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		action(MockCtx(http.MethodGet, "/"))
+	}()
+	assert.Equal(http.ErrAbortHandler, recovered)
+}
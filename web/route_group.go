@@ -0,0 +1,94 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Group returns a new route group for the app with a given path prefix and middleware.
+func (a *App) Group(prefix string, middleware ...Middleware) *RouteGroup {
+	return &RouteGroup{
+		App:        a,
+		Prefix:     strings.TrimSuffix(prefix, "/"),
+		Middleware: middleware,
+	}
+}
+
+// RouteGroup registers routes on an app with a shared path prefix and middleware.
+//
+// Group middleware is applied outside of any per-route middleware, and inside
+// the app's base middleware.
+type RouteGroup struct {
+	App        *App
+	Prefix     string
+	Middleware []Middleware
+}
+
+// Group returns a nested route group.
+//
+// The nested group's prefix is appended to this group's prefix, and this group's
+// middleware is applied outside the nested group's middleware.
+func (rg *RouteGroup) Group(prefix string, middleware ...Middleware) *RouteGroup {
+	return &RouteGroup{
+		App:        rg.App,
+		Prefix:     rg.Prefix + strings.TrimSuffix(prefix, "/"),
+		Middleware: rg.nestMiddleware(middleware),
+	}
+}
+
+// GET registers a GET request route handler with the given middleware.
+func (rg *RouteGroup) GET(path string, action Action, middleware ...Middleware) {
+	rg.Method(http.MethodGet, path, action, middleware...)
+}
+
+// OPTIONS registers a OPTIONS request route handler the given middleware.
+func (rg *RouteGroup) OPTIONS(path string, action Action, middleware ...Middleware) {
+	rg.Method(http.MethodOptions, path, action, middleware...)
+}
+
+// HEAD registers a HEAD request route handler with the given middleware.
+func (rg *RouteGroup) HEAD(path string, action Action, middleware ...Middleware) {
+	rg.Method(http.MethodHead, path, action, middleware...)
+}
+
+// PUT registers a PUT request route handler with the given middleware.
+func (rg *RouteGroup) PUT(path string, action Action, middleware ...Middleware) {
+	rg.Method(http.MethodPut, path, action, middleware...)
+}
+
+// PATCH registers a PATCH request route handler with the given middleware.
+func (rg *RouteGroup) PATCH(path string, action Action, middleware ...Middleware) {
+	rg.Method(http.MethodPatch, path, action, middleware...)
+}
+
+// POST registers a POST request route handler with the given middleware.
+func (rg *RouteGroup) POST(path string, action Action, middleware ...Middleware) {
+	rg.Method(http.MethodPost, path, action, middleware...)
+}
+
+// DELETE registers a DELETE request route handler with the given middleware.
+func (rg *RouteGroup) DELETE(path string, action Action, middleware ...Middleware) {
+	rg.Method(http.MethodDelete, path, action, middleware...)
+}
+
+// Method registers an action for a given method and path with the given middleware.
+//
+// The path is prefixed with the group prefix.
+func (rg *RouteGroup) Method(method string, path string, action Action, middleware ...Middleware) {
+	rg.App.Method(method, rg.Prefix+path, action, rg.nestMiddleware(middleware)...)
+}
+
+// nestMiddleware returns the given middleware followed by the group middleware
+// such that, per `NestMiddleware`, the group middleware is called first.
+func (rg *RouteGroup) nestMiddleware(middleware []Middleware) []Middleware {
+	output := make([]Middleware, 0, len(middleware)+len(rg.Middleware))
+	output = append(output, middleware...)
+	return append(output, rg.Middleware...)
+}
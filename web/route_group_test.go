@@ -0,0 +1,61 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func Test_RouteGroup(t *testing.T) {
+	its := assert.New(t)
+
+	var calls []string
+	var record = func(name string) Middleware {
+		return func(action Action) Action {
+			return func(r *Ctx) Result {
+				calls = append(calls, name)
+				return action(r)
+			}
+		}
+	}
+	var ok = func(_ *Ctx) Result { return Raw([]byte("OK!")) }
+
+	app, err := New(OptUse(record("base")))
+	its.Nil(err)
+
+	api := app.Group("/api/", record("api"))
+	api.GET("/status", ok)
+	v1 := api.Group("/v1", record("v1"))
+	v1.POST("/things", ok, record("route"))
+	v1.DELETE("/things/:id", ok)
+
+	res, err := MockGet(app, "/api/status").Discard()
+	its.Nil(err)
+	its.Equal(http.StatusOK, res.StatusCode)
+	its.Equal([]string{"base", "api"}, calls)
+
+	calls = nil
+	res, err = MockMethod(app, http.MethodPost, "/api/v1/things").Discard()
+	its.Nil(err)
+	its.Equal(http.StatusOK, res.StatusCode)
+	its.Equal([]string{"base", "api", "v1", "route"}, calls)
+
+	calls = nil
+	res, err = MockMethod(app, http.MethodDelete, "/api/v1/things/1234").Discard()
+	its.Nil(err)
+	its.Equal(http.StatusOK, res.StatusCode)
+	its.Equal([]string{"base", "api", "v1"}, calls)
+
+	calls = nil
+	res, err = MockGet(app, "/status").Discard()
+	its.Nil(err)
+	its.Equal(http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,66 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"sort"
+	"strings"
+)
+
+// RouteInfo describes a registered route.
+type RouteInfo struct {
+	// Method is the http method the route is registered for.
+	Method string
+	// Path is the registered path pattern, e.g. `/users/:id`.
+	Path string
+	// ParamNames are the names of the parameters in the path, in order.
+	ParamNames []string
+}
+
+// ListRoutes returns the registered routes, sorted by path and then method.
+//
+// It is named so as not to shadow the `Routes` field on the embedding `App`.
+func (rt *RouteTree) ListRoutes() (output []RouteInfo) {
+	for _, root := range rt.Routes {
+		output = appendRouteInfos(output, root)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Path == output[j].Path {
+			return output[i].Method < output[j].Method
+		}
+		return output[i].Path < output[j].Path
+	})
+	return
+}
+
+func appendRouteInfos(output []RouteInfo, n *RouteNode) []RouteInfo {
+	if n == nil {
+		return output
+	}
+	if n.Route != nil {
+		output = append(output, RouteInfo{
+			Method:     n.Route.Method,
+			Path:       n.Route.Path,
+			ParamNames: routeParamNames(n.Route.Path),
+		})
+	}
+	for _, child := range n.Children {
+		output = appendRouteInfos(output, child)
+	}
+	return output
+}
+
+// routeParamNames returns the names of the named and catch-all parameters in a path.
+func routeParamNames(path string) (output []string) {
+	for _, segment := range strings.Split(path, "/") {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			output = append(output, routeParamName(segment))
+		}
+	}
+	return
+}
@@ -0,0 +1,59 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func Test_RouteTree_ListRoutes(t *testing.T) {
+	its := assert.New(t)
+
+	app := MustNew()
+	its.Empty(app.ListRoutes())
+
+	app.GET("/", controllerNoOp)
+	app.GET("/info/:user/project/:project", controllerNoOp)
+	app.POST("/info/:user", controllerNoOp)
+	app.GET("/info/:user", controllerNoOp)
+	app.GET("/static/*filepath", controllerNoOp)
+
+	its.Equal([]RouteInfo{
+		{Method: http.MethodGet, Path: "/"},
+		{Method: http.MethodGet, Path: "/info/:user", ParamNames: []string{"user"}},
+		{Method: http.MethodPost, Path: "/info/:user", ParamNames: []string{"user"}},
+		{Method: http.MethodGet, Path: "/info/:user/project/:project", ParamNames: []string{"user", "project"}},
+		{Method: http.MethodGet, Path: "/static/*filepath", ParamNames: []string{"filepath"}},
+	}, app.ListRoutes())
+}
+
+func Test_RouteTree_ParamConstraint(t *testing.T) {
+	its := assert.New(t)
+
+	app := MustNew()
+	app.GET(`/users/:id(\d+)`, func(r *Ctx) Result {
+		id, _ := r.RouteParam("id")
+		return Text.Result(id)
+	})
+
+	its.Equal([]RouteInfo{
+		{Method: http.MethodGet, Path: `/users/:id(\d+)`, ParamNames: []string{"id"}},
+	}, app.ListRoutes())
+
+	contents, meta, err := MockGet(app, "/users/123").Bytes()
+	its.Nil(err)
+	its.Equal(http.StatusOK, meta.StatusCode)
+	its.Equal("123", string(contents))
+
+	meta, err = MockGet(app, "/users/abc").Discard()
+	its.Nil(err)
+	its.Equal(http.StatusNotFound, meta.StatusCode)
+}
@@ -12,6 +12,7 @@ Use of this source code is governed by a MIT license that can be found in the LI
 package web
 
 import (
+	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -39,6 +40,10 @@ type RouteNode struct {
 	Children   []*RouteNode
 	Route      *Route
 	Priority   uint32
+
+	// Constraint is an optional regex a param value must match, e.g. `:id(\d+)`.
+	// It is compiled when the route is added.
+	Constraint *regexp.Regexp
 }
 
 // GetPath returns the node for a path, parameter values, and if there is a trailing slash redirect
@@ -217,6 +222,8 @@ func (n *RouteNode) insertChild(numParams uint8, method, path, fullPath string,
 			case ':', '*':
 				panic("only one wildcard per path segment is allowed, has: '" +
 					path[i:] + "' in path '" + fullPath + "'")
+			case '(':
+				end = constraintEnd(path, end, fullPath)
 			default:
 				end++
 			}
@@ -244,6 +251,7 @@ func (n *RouteNode) insertChild(numParams uint8, method, path, fullPath string,
 			child := &RouteNode{
 				RouteNodeType: RouteNodeTypeParam,
 				MaxParams:     numParams,
+				Constraint:    compileConstraint(path[i:end], fullPath),
 			}
 			n.Children = []*RouteNode{child}
 			n.IsWildcard = true
@@ -264,11 +272,19 @@ func (n *RouteNode) insertChild(numParams uint8, method, path, fullPath string,
 				n.Children = []*RouteNode{child}
 				n = child
 			}
+
+			// resume scanning after the wildcard so that a constraint
+			// containing ':' or '*' isn't mistaken for another wildcard
+			i = end - 1
 		} else { // catchAll
 			if end != max || numParams > 1 {
 				panic("catch-all routes are only allowed at the end of the path in path '" + fullPath + "'")
 			}
 
+			if strings.IndexByte(path[i:end], '(') >= 0 {
+				panic("constraints are only allowed on named parameters in path '" + fullPath + "'")
+			}
+
 			if len(n.Path) > 0 && n.Path[len(n.Path)-1] == '/' {
 				panic("catch-all conflicts with existing handle for the path segment root in path '" + fullPath + "'")
 			}
@@ -359,12 +375,17 @@ walk: // outer loop for walking the tree
 						end++
 					}
 
+					// a constrained param that doesn't match is not found
+					if n.Constraint != nil && !n.Constraint.MatchString(path[:end]) {
+						return
+					}
+
 					// save param value
 					if p == nil {
 						// lazy allocation
 						p = make(RouteParameters)
 					}
-					p[n.Path[1:]] = path[:end]
+					p[routeParamName(n.Path)] = path[:end]
 
 					// we need to go deeper!
 					if end < len(path) {
@@ -570,6 +591,10 @@ walk: // outer loop for walking the tree
 					k++
 				}
 
+				if n.Constraint != nil && !n.Constraint.MatchString(path[:k]) {
+					return ciPath, false
+				}
+
 				// add param value to case insensitive path
 				ciPath = append(ciPath, path[:k]...)
 
@@ -765,9 +790,77 @@ func bufApp(buf *[]byte, s string, w int, c byte) {
 	(*buf)[w] = c
 }
 
+// constraintEnd returns the index after the closing paren of a param constraint
+// that opens at a given index, e.g. the `(\d+)` in `:id(\d+)`.
+//
+// It panics if the constraint is unterminated, contains a '/', or doesn't end the path segment.
+func constraintEnd(path string, start int, fullPath string) int {
+	depth := 0
+	for end := start; end < len(path); end++ {
+		switch path[end] {
+		case '\\':
+			end++
+		case '/':
+			panic("constraints must not contain '/' in path '" + fullPath + "'")
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				if end+1 < len(path) && path[end+1] != '/' {
+					panic("constraints must end the path segment in path '" + fullPath + "'")
+				}
+				return end + 1
+			}
+		}
+	}
+	panic("unterminated constraint in path '" + fullPath + "'")
+}
+
+// compileConstraint compiles the regex constraint for a param segment, e.g. `:id(\d+)`.
+// It returns nil if the segment has no constraint.
+func compileConstraint(segment, fullPath string) *regexp.Regexp {
+	index := strings.IndexByte(segment, '(')
+	if index < 0 {
+		return nil
+	}
+	if index < 2 {
+		panic("wildcards must be named with a non-empty name in path '" + fullPath + "'")
+	}
+	constraint, err := regexp.Compile("^(?:" + segment[index+1:len(segment)-1] + ")$")
+	if err != nil {
+		panic("invalid constraint '" + segment[index:] + "' in path '" + fullPath + "': " + err.Error())
+	}
+	return constraint
+}
+
+// routeParamName returns the name of a param segment without its constraint,
+// e.g. `id` for `:id(\d+)`.
+func routeParamName(segment string) string {
+	if index := strings.IndexByte(segment, '('); index > 0 {
+		return segment[1:index]
+	}
+	return segment[1:]
+}
+
 func countParams(path string) uint8 {
 	var n uint
 	for i := 0; i < len(path); i++ {
+		if path[i] == '(' {
+			// skip over constraints, which may contain ':' or '*'
+			for depth := 0; i < len(path); i++ {
+				if path[i] == '\\' {
+					i++
+				} else if path[i] == '(' {
+					depth++
+				} else if path[i] == ')' {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			continue
+		}
 		if path[i] != ':' && path[i] != '*' {
 			continue
 		}
@@ -121,6 +121,9 @@ func TestCountParams(t *testing.T) {
 	if countParams(strings.Repeat("/:param", 256)) != 255 {
 		t.Fail()
 	}
+	if countParams(`/path/:param1(\d+)/:param2((?:a|b)*)/*catch-all`) != 3 {
+		t.Fail()
+	}
 }
 
 func TestTreeAddAndGet(t *testing.T) {
@@ -209,6 +212,81 @@ func TestTreeWildcard(t *testing.T) {
 	checkMaxParams(t, tree)
 }
 
+func TestTreeParamConstraint(t *testing.T) {
+	tree := &RouteNode{}
+
+	routes := [...]string{
+		`/users/:id(\d+)`,
+		`/users/:id(\d+)/posts/:slug([a-z-]+)`,
+		`/teams/:team((?:red|blue))/`,
+		`/files/:dir(\w+)/*filepath`,
+		`/search/:query`,
+	}
+	for _, route := range routes {
+		tree.AddRoute("GET", route, fakeHandler(route))
+	}
+
+	checkRequests(t, tree, testRequests{
+		{"/users/123", false, `/users/:id(\d+)`, RouteParameters{"id": "123"}},
+		{"/users/abc", true, "", nil},
+		{"/users/", true, "", nil},
+		{"/users/123/posts/hello-world", false, `/users/:id(\d+)/posts/:slug([a-z-]+)`, RouteParameters{"id": "123", "slug": "hello-world"}},
+		{"/users/123/posts/Hello", true, "", RouteParameters{"id": "123"}},
+		{"/users/abc/posts/hello", true, "", nil},
+		{"/teams/red/", false, `/teams/:team((?:red|blue))/`, RouteParameters{"team": "red"}},
+		{"/teams/green/", true, "", nil},
+		{"/files/js/inc/framework.js", false, `/files/:dir(\w+)/*filepath`, RouteParameters{"dir": "js", "filepath": "/inc/framework.js"}},
+		{"/files/j.s/inc/framework.js", true, "", nil},
+		{"/search/anything", false, "/search/:query", RouteParameters{"query": "anything"}},
+	})
+
+	checkPriorities(t, tree)
+	checkMaxParams(t, tree)
+
+	if out, found := tree.findCaseInsensitivePath("/USERS/123", true); !found || string(out) != "/users/123" {
+		t.Errorf("expected case insensitive match for '/USERS/123', got '%s' %t", string(out), found)
+	}
+	if _, found := tree.findCaseInsensitivePath("/USERS/abc", true); found {
+		t.Errorf("unexpected case insensitive match for '/USERS/abc'")
+	}
+}
+
+func TestTreeParamConstraintConflict(t *testing.T) {
+	routes := []testRoute{
+		{`/users/:id(\d+)`, false},
+		{`/users/:id`, true},
+		{`/users/:id([a-z]+)`, true},
+		{`/users/:name(\d+)`, true},
+		{`/users/new`, true},
+		{`/users/:id(\d+)/posts`, false},
+		{`/teams/:team`, false},
+		{`/teams/:team(\d+)`, true},
+		{`/orgs/:org(\d+)/:repo`, false},
+		{`/orgs/:org(\d+)/:repo(\w+)`, true},
+	}
+	testRoutes(t, routes)
+}
+
+func TestTreeParamConstraintInvalid(t *testing.T) {
+	routes := [...]string{
+		`/users/:id(\d+`,
+		`/users/:id([)`,
+		`/users/:id(\d+)x`,
+		`/users/:id(a/b)`,
+		`/users/:(\d+)`,
+		`/files/*filepath(.*)`,
+	}
+	for _, route := range routes {
+		tree := &RouteNode{}
+		recv := catchPanic(func() {
+			tree.AddRoute("GET", route, nil)
+		})
+		if recv == nil {
+			t.Errorf("no panic while inserting route with invalid constraint '%s'", route)
+		}
+	}
+}
+
 func catchPanic(testFunc func()) (recv interface{}) {
 	defer func() {
 		recv = recover()
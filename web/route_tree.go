@@ -9,6 +9,8 @@ package web
 
 import (
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/blend/go-sdk/webutil"
 )
@@ -38,6 +40,10 @@ type RouteTree struct {
 	// the request has a '/' suffix and the
 	// registered route does not.
 	SkipTrailingSlashRedirects bool
+	// RedirectFixedPath enables redirecting requests that do not
+	// match a route to the canonical path of a route that matches
+	// case-insensitively, after cleaning the request path.
+	RedirectFixedPath bool
 	// SkipHandlingMethodOptions disables returning
 	// a result with the `ALLOWED` header for method options,
 	// and will instead 404 for `OPTIONS` methods.
@@ -46,12 +52,51 @@ type RouteTree struct {
 	// for methods that do not have a route tree with
 	// a specific 405 response, and will instead return a 404.
 	SkipMethodNotAllowed bool
+	// SkipAutoHead disables serving `HEAD` requests that do not
+	// match a `HEAD` route with the `GET` route for the same path.
+	SkipAutoHead bool
 	// NotFoundHandler is an optional handler to set
 	// to customize not found (404) results.
 	NotFoundHandler Handler
 	// MethodNotAllowedHandler is an optional handler
 	// to set to customize method not allowed (405) results.
 	MethodNotAllowedHandler Handler
+	// OptionsHandler is an optional handler to set
+	// to customize automatic `OPTIONS` results, e.g. for CORS preflight requests.
+	OptionsHandler Handler
+
+	// mounts are the handlers for path prefixes, longest prefix first.
+	mounts []*Route
+}
+
+// Mount adds a handler for every method and path under a given prefix, e.g. `/debug/`.
+//
+// Mounted handlers are only used for requests that do not match a registered route,
+// so routes under the prefix can still be registered separately. The handler is passed
+// a route with the path `<prefix>*filepath`, and the remainder of the request path
+// (with a leading `/`) as the `filepath` parameter, as for a catch-all route.
+func (rt *RouteTree) Mount(prefix string, handler Handler) {
+	if len(prefix) == 0 || prefix[0] != '/' {
+		panic("mount prefix must begin with '/' in prefix '" + prefix + "'")
+	}
+	prefix = strings.TrimSuffix(prefix, "*"+RouteTokenFilepath)
+	if !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+	path := prefix + "*" + RouteTokenFilepath
+	for _, mount := range rt.mounts {
+		if mount.Path == path {
+			panic("a handle is already mounted for prefix '" + prefix + "'")
+		}
+	}
+	rt.mounts = append(rt.mounts, &Route{
+		Handler: handler,
+		Path:    path,
+		Params:  []string{RouteTokenFilepath},
+	})
+	sort.SliceStable(rt.mounts, func(i, j int) bool {
+		return len(rt.mounts[i].Path) > len(rt.mounts[j].Path)
+	})
 }
 
 // Handle adds a handler at a given method and path.
@@ -92,7 +137,14 @@ func (rt *RouteTree) Route(req *http.Request) (*Route, RouteParameters) {
 			return route, params
 		}
 	}
-	return nil, nil
+	if req.Method == http.MethodHead && !rt.SkipAutoHead {
+		if getRoot := rt.Routes[http.MethodGet]; getRoot != nil {
+			if route, params, _ := getRoot.getValue(path); route != nil {
+				return route, params
+			}
+		}
+	}
+	return rt.mounted(req.Method, path)
 }
 
 // ServeHTTP makes the router implement the http.Handler interface.
@@ -108,14 +160,42 @@ func (rt *RouteTree) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				rt.redirectTrailingSlash(w, req)
 				return
 			}
+			if rt.RedirectFixedPath {
+				fixedPath, found := root.findCaseInsensitivePath(CleanPath(path), !rt.SkipTrailingSlashRedirects)
+				if found {
+					rt.redirectFixedPath(w, req, string(fixedPath))
+					return
+				}
+			}
 		}
 	}
 
+	if req.Method == http.MethodHead && !rt.SkipAutoHead {
+		// Handle HEAD requests with the GET handler for the path
+		if root := rt.Routes[http.MethodGet]; root != nil {
+			if route, params, _ := root.getValue(path); route != nil {
+				hw := &headResponseWriter{ResponseWriter: w}
+				route.Handler(hw, req, route, params)
+				hw.writeHeader()
+				return
+			}
+		}
+	}
+
+	if route, params := rt.mounted(req.Method, path); route != nil {
+		route.Handler(w, req, route, params)
+		return
+	}
+
 	if req.Method == http.MethodOptions {
 		// Handle OPTIONS requests
 		if !rt.SkipHandlingMethodOptions {
 			if allow := rt.allowed(path, req.Method); allow != "" {
 				w.Header().Set(webutil.HeaderAllow, allow)
+				if rt.OptionsHandler != nil {
+					rt.OptionsHandler(w, req, nil, nil)
+					return
+				}
 				// just return the allowed header
 				return
 			}
@@ -148,6 +228,24 @@ func (rt *RouteTree) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 // internal helpers
 //
 
+// mounted returns the route for the mount with the longest prefix of a given path, if there is one.
+func (rt *RouteTree) mounted(method, path string) (*Route, RouteParameters) {
+	for _, mount := range rt.mounts {
+		// the prefix includes the trailing slash, e.g. `/debug/`.
+		prefix := mount.Path[:len(mount.Path)-len(RouteTokenFilepath)-1]
+		if strings.HasPrefix(path, prefix) || path == prefix[:len(prefix)-1] {
+			route := *mount
+			route.Method = method
+			filepath := "/"
+			if len(path) > len(prefix) {
+				filepath = path[len(prefix)-1:]
+			}
+			return &route, RouteParameters{RouteTokenFilepath: filepath}
+		}
+	}
+	return nil, nil
+}
+
 // withTrailingSlash returns the request with a `/` suffix on the url path.
 func (rt *RouteTree) withTrailingSlash(req *http.Request) *http.Request {
 	path := req.URL.Path
@@ -162,31 +260,45 @@ func (rt *RouteTree) withTrailingSlash(req *http.Request) *http.Request {
 // redirectTrailingSlash redirects the request if a suffix trailing
 // forward slash should be added.
 func (rt *RouteTree) redirectTrailingSlash(w http.ResponseWriter, req *http.Request) {
-	code := http.StatusMovedPermanently // 301 // Permanent redirect, request with GET method
-	if req.Method != http.MethodGet {
-		code = http.StatusTemporaryRedirect // 307
-	}
 	req = rt.withTrailingSlash(req)
-	http.Redirect(w, req, req.URL.String(), code)
-	return
+	http.Redirect(w, req, req.URL.String(), rt.redirectStatusCode(req))
+}
+
+// redirectFixedPath redirects the request to the case corrected path.
+func (rt *RouteTree) redirectFixedPath(w http.ResponseWriter, req *http.Request, fixedPath string) {
+	req.URL.Path = fixedPath
+	http.Redirect(w, req, req.URL.String(), rt.redirectStatusCode(req))
+}
+
+// redirectStatusCode returns the permanent redirect status code for a request.
+//
+// Requests that are not `GET` or `HEAD` use a 308 so that clients preserve
+// the method and body when following the redirect.
+func (rt *RouteTree) redirectStatusCode(req *http.Request) int {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return http.StatusMovedPermanently // 301
+	}
+	return http.StatusPermanentRedirect // 308
 }
 
-func (rt *RouteTree) allowed(path, reqMethod string) (allow string) {
+// allowed returns the value of the `Allow` header for a given path,
+// ignoring the requested method.
+//
+// Methods are sorted so the header is stable between requests, and `HEAD` is
+// included for paths with a `GET` route unless `SkipAutoHead` is set.
+func (rt *RouteTree) allowed(path, reqMethod string) string {
+	var methods []string
 	if path == "*" { // server-wide
 		for method := range rt.Routes {
 			if method == http.MethodOptions {
 				continue
 			}
-
-			// add request method to list of allowed methods
-			if allow == "" {
-				allow = method
-			} else {
-				allow += ", " + method
-			}
+			methods = append(methods, method)
 		}
-		return
+		sort.Strings(methods)
+		return strings.Join(methods, ", ")
 	}
+	var hasGet, hasHead bool
 	for method := range rt.Routes {
 		// Skip the requested method - we already tried this one
 		if method == reqMethod || method == http.MethodOptions {
@@ -195,16 +307,17 @@ func (rt *RouteTree) allowed(path, reqMethod string) (allow string) {
 
 		handle, _, _ := rt.Routes[method].getValue(path)
 		if handle != nil {
-			// add request method to list of allowed methods
-			if allow == "" {
-				allow = method
-			} else {
-				allow += ", " + method
-			}
+			hasGet = hasGet || method == http.MethodGet
+			hasHead = hasHead || method == http.MethodHead
+			methods = append(methods, method)
 		}
 	}
-	if allow != "" {
-		allow += ", " + http.MethodOptions
+	if len(methods) == 0 {
+		return ""
+	}
+	if hasGet && !hasHead && reqMethod != http.MethodHead && !rt.SkipAutoHead {
+		methods = append(methods, http.MethodHead)
 	}
-	return
+	sort.Strings(methods)
+	return strings.Join(append(methods, http.MethodOptions), ", ")
 }
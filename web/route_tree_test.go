@@ -51,18 +51,27 @@ func Test_RouteTree_allowed(t *testing.T) {
 
 	rt.Handle(http.MethodGet, "/hello", handlerNoOp)
 	allowed = strings.Split(rt.allowed("/hello", ""), ", ")
-	its.Len(allowed, 2)
+	its.Len(allowed, 3)
 	its.Any(allowed, func(i interface{}) bool {
 		s, ok := i.(string)
 		return ok && s == "GET"
 	})
+	its.Any(allowed, func(i interface{}) bool {
+		s, ok := i.(string)
+		return ok && s == "HEAD"
+	})
 	its.Any(allowed, func(i interface{}) bool {
 		s, ok := i.(string)
 		return ok && s == "OPTIONS"
 	})
+	its.Equal("GET, OPTIONS", rt.allowed("/hello", http.MethodHead))
+	rt.SkipAutoHead = true
+	its.Equal("GET, OPTIONS", rt.allowed("/hello", ""))
+	rt.SkipAutoHead = false
+
 	rt.Handle(http.MethodPost, "/hello", handlerNoOp)
 	allowed = strings.Split(rt.allowed("/hello", ""), ", ")
-	its.Len(allowed, 3)
+	its.Len(allowed, 4)
 
 	rt.Handle(http.MethodOptions, "/hello", handlerNoOp)
 	rt.Handle(http.MethodHead, "/hello", handlerNoOp)
@@ -77,6 +86,11 @@ func Test_RouteTree_allowed(t *testing.T) {
 	rt.Handle(http.MethodPatch, "/hello", handlerNoOp)
 	allowed = strings.Split(rt.allowed("/hello", ""), ", ")
 	its.Len(allowed, 7)
+
+	// the header should be stable, and omit the requested method
+	its.Equal("DELETE, GET, HEAD, PATCH, POST, PUT, OPTIONS", rt.allowed("/hello", ""))
+	its.Equal("DELETE, GET, HEAD, PATCH, PUT, OPTIONS", rt.allowed("/hello", http.MethodPost))
+	its.Equal("DELETE, GET, HEAD, PATCH, POST, PUT", rt.allowed("*", ""))
 }
 
 func Test_RouteTree_Route(t *testing.T) {
@@ -243,7 +257,13 @@ func Test_RouteTree_ServeHTTP(t *testing.T) {
 	its.Equal(http.StatusOK, res.StatusCode)
 	allowedHeader := res.Header.Get(webutil.HeaderAllow)
 	its.NotEmpty(allowedHeader)
-	its.Equal("GET, OPTIONS", allowedHeader)
+	its.Equal("GET, HEAD, OPTIONS", allowedHeader)
+
+	postReq, _ := http.NewRequest(http.MethodPost, mock.URL, nil)
+	res, err = mock.Client().Do(postReq)
+	its.Nil(err)
+	its.Equal(http.StatusMethodNotAllowed, res.StatusCode)
+	its.Equal("GET, HEAD, OPTIONS", res.Header.Get(webutil.HeaderAllow))
 
 	rt.SkipHandlingMethodOptions = true
 	res, err = mock.Client().Do(optionsReq)
@@ -261,6 +281,8 @@ func Test_RouteTree_ServeHTTP(t *testing.T) {
 	its.Empty(allowedHeader)
 	its.Equal(1, notFoundCalls)
 
+	// HEAD requests are served by GET routes unless auto head is disabled
+	rt.SkipAutoHead = true
 	headReq, _ := http.NewRequest(http.MethodHead, mock.URL, nil)
 	res, err = mock.Client().Do(headReq)
 	its.Nil(err)
@@ -296,3 +318,134 @@ func Test_RouteTree_ServeHTTP(t *testing.T) {
 	its.Empty(allowedHeader)
 	its.Equal(2, notFoundCalls)
 }
+
+func Test_RouteTree_ServeHTTP_redirectFixedPath(t *testing.T) {
+	its := assert.New(t)
+
+	rt := new(RouteTree)
+	rt.Handle(http.MethodGet, "/foo/bar", handlerNoOp)
+	rt.Handle(http.MethodGet, "/FOO/baz", handlerNoOp)
+	rt.Handle(http.MethodGet, "/foo/baz", handlerNoOp)
+	rt.Handle(http.MethodPost, "/users/:id", handlerNoOp)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, httptest.NewRequest(method, path, nil))
+		return res
+	}
+
+	res := serve(http.MethodGet, "/FOO/Bar")
+	its.Equal(http.StatusNotFound, res.Code)
+
+	rt.RedirectFixedPath = true
+
+	res = serve(http.MethodGet, "/FOO/Bar")
+	its.Equal(http.StatusMovedPermanently, res.Code)
+	its.Equal("/foo/bar", res.Header().Get("Location"))
+
+	res = serve(http.MethodGet, "/foo/../FOO/BAR/")
+	its.Equal(http.StatusMovedPermanently, res.Code)
+	its.Equal("/foo/bar", res.Header().Get("Location"))
+
+	res = serve(http.MethodPost, "/Users/1234")
+	its.Equal(http.StatusPermanentRedirect, res.Code)
+	its.Equal("/users/1234", res.Header().Get("Location"))
+
+	// exact matches are preferred over fixed paths
+	res = serve(http.MethodGet, "/FOO/baz")
+	its.Equal(http.StatusOK, res.Code)
+	res = serve(http.MethodGet, "/foo/baz")
+	its.Equal(http.StatusOK, res.Code)
+
+	res = serve(http.MethodGet, "/not/found")
+	its.Equal(http.StatusNotFound, res.Code)
+}
+
+func Test_RouteTree_ServeHTTP_redirectTrailingSlash(t *testing.T) {
+	its := assert.New(t)
+
+	rt := new(RouteTree)
+	rt.Handle(http.MethodGet, "/foo", handlerNoOp)
+	rt.Handle(http.MethodHead, "/foo", handlerNoOp)
+	rt.Handle(http.MethodPost, "/bar/", handlerNoOp)
+	rt.Handle(http.MethodGet, "/static/*filepath", handlerNoOp)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, httptest.NewRequest(method, path, nil))
+		return res
+	}
+
+	res := serve(http.MethodGet, "/foo/")
+	its.Equal(http.StatusMovedPermanently, res.Code)
+	its.Equal("/foo", res.Header().Get("Location"))
+
+	res = serve(http.MethodHead, "/foo/")
+	its.Equal(http.StatusMovedPermanently, res.Code)
+
+	res = serve(http.MethodPost, "/bar")
+	its.Equal(http.StatusPermanentRedirect, res.Code)
+	its.Equal("/bar/", res.Header().Get("Location"))
+
+	// the catch-all route matches without a redirect
+	res = serve(http.MethodGet, "/static/")
+	its.Equal(http.StatusOK, res.Code)
+
+	rt.SkipTrailingSlashRedirects = true
+	res = serve(http.MethodGet, "/foo/")
+	its.Equal(http.StatusNotFound, res.Code)
+}
+
+func Test_RouteTree_ServeHTTP_autoHead(t *testing.T) {
+	its := assert.New(t)
+
+	rt := new(RouteTree)
+	rt.Handle(http.MethodGet, "/hello", func(rw http.ResponseWriter, _ *http.Request, _ *Route, _ RouteParameters) {
+		rw.Header().Set("X-Test", "hello")
+		rw.WriteHeader(http.StatusOK)
+		fmt.Fprint(rw, "Hello World!")
+	})
+	rt.Handle(http.MethodGet, "/explicit", handlerNoOp)
+	rt.Handle(http.MethodGet, "/sized", func(rw http.ResponseWriter, _ *http.Request, _ *Route, _ RouteParameters) {
+		rw.Header().Set(webutil.HeaderContentLength, "1024")
+		rw.WriteHeader(http.StatusOK)
+	})
+	rt.Handle(http.MethodHead, "/explicit", func(rw http.ResponseWriter, _ *http.Request, _ *Route, _ RouteParameters) {
+		rw.Header().Set("X-Test", "explicit")
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, httptest.NewRequest(method, path, nil))
+		return res
+	}
+
+	res := serve(http.MethodHead, "/hello")
+	its.Equal(http.StatusOK, res.Code)
+	its.Equal("hello", res.Header().Get("X-Test"))
+	its.Equal("12", res.Header().Get(webutil.HeaderContentLength))
+	its.Empty(res.Body.Bytes())
+
+	res = serve(http.MethodHead, "/sized")
+	its.Equal(http.StatusOK, res.Code)
+	its.Equal("1024", res.Header().Get(webutil.HeaderContentLength))
+
+	res = serve(http.MethodHead, "/explicit")
+	its.Equal(http.StatusAccepted, res.Code)
+	its.Equal("explicit", res.Header().Get("X-Test"))
+
+	res = serve(http.MethodHead, "/missing")
+	its.Equal(http.StatusNotFound, res.Code)
+
+	route, params := rt.Route(httptest.NewRequest(http.MethodHead, "/hello", nil))
+	its.NotNil(route)
+	its.Equal(http.MethodGet, route.Method)
+	its.Empty(params)
+
+	rt.SkipAutoHead = true
+	res = serve(http.MethodHead, "/hello")
+	its.Equal(http.StatusMethodNotAllowed, res.Code)
+	route, _ = rt.Route(httptest.NewRequest(http.MethodHead, "/hello", nil))
+	its.Nil(route)
+}
@@ -11,8 +11,10 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
+	"strings"
 	"sync"
 
 	"github.com/blend/go-sdk/logger"
@@ -28,6 +30,19 @@ func NewStaticFileServer(options ...StaticFileserverOption) *StaticFileServer {
 	return &sfs
 }
 
+// StaticFileSystem returns an action that serves files from a given file system
+// using the `filepath` route parameter, e.g. for a route like `/static/*filepath`.
+//
+// Files are read from the file system for each request, directories are not listed,
+// missing files return a not found result, and paths that traverse outside
+// the file system root with `..` return a bad request result.
+func StaticFileSystem(fs http.FileSystem) Action {
+	return NewStaticFileServer(
+		OptStaticFileServerSearchPaths(fs),
+		OptStaticFileServerCacheDisabled(true),
+	).Action
+}
+
 // StaticFileserverOption are options for static fileservers.
 type StaticFileserverOption func(*StaticFileServer)
 
@@ -156,6 +171,10 @@ func (sc *StaticFileServer) ServeCachedFile(r *Ctx, filepath string) Result {
 // First the file path is modified according to the rewrite rules.
 // Then each search path is checked for the resolved file path.
 func (sc *StaticFileServer) ResolveFile(filePath string) (f http.File, finalPath string, err error) {
+	if containsDotDot(filePath) {
+		err = NewParameterInvalidError(RouteTokenFilepath, "cannot traverse parent directories")
+		return
+	}
 	for _, rule := range sc.RewriteRules {
 		if matched, newFilePath := rule.Apply(filePath); matched {
 			filePath = newFilePath
@@ -243,6 +262,13 @@ func (sc *StaticFileServer) ResolveCachedFile(filepath string) (*CachedStaticFil
 }
 
 func (sc *StaticFileServer) fileError(r *Ctx, err error) Result {
+	if IsErrParameterInvalid(err) {
+		if r.DefaultProvider != nil {
+			return r.DefaultProvider.BadRequest(err)
+		}
+		http.Error(r.Response, err.Error(), http.StatusBadRequest)
+		return nil
+	}
 	if os.IsNotExist(err) {
 		if r.DefaultProvider != nil {
 			return r.DefaultProvider.NotFound()
@@ -256,3 +282,23 @@ func (sc *StaticFileServer) fileError(r *Ctx, err error) Result {
 	http.Error(r.Response, err.Error(), http.StatusInternalServerError)
 	return nil
 }
+
+// containsDotDot returns if a given file path has any `..` segments,
+// including segments that are still url encoded.
+func containsDotDot(filePath string) bool {
+	if unescaped, err := url.PathUnescape(filePath); err == nil && unescaped != filePath {
+		if containsDotDot(unescaped) {
+			return true
+		}
+	}
+	for _, segment := range strings.FieldsFunc(filePath, isSlash) {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+func isSlash(r rune) bool {
+	return r == '/' || r == '\\'
+}
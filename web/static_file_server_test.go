@@ -220,3 +220,50 @@ func TestStaticFileserverAddsETag(t *testing.T) {
 	assert.NotEmpty(buffer.Bytes())
 	assert.NotEmpty(res.Header().Get(webutil.HeaderETag))
 }
+
+func TestStaticFileSystem(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/static/*filepath", StaticFileSystem(http.Dir("testdata")))
+
+	body, meta, err := MockGet(app, "/static/test_file.html").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.HasPrefix(meta.Header.Get(webutil.HeaderContentType), "text/html")
+	assert.NotEmpty(body)
+
+	meta, err = MockGet(app, "/static/not_a_file.html").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, meta.StatusCode)
+
+	meta, err = MockGet(app, "/static/").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, meta.StatusCode)
+}
+
+func TestStaticFileSystemTraversal(t *testing.T) {
+	assert := assert.New(t)
+
+	action := StaticFileSystem(http.Dir("testdata"))
+	for _, filePath := range []string{
+		"../app.go",
+		"/../app.go",
+		"foo/../../app.go",
+		"%2e%2e/app.go",
+		"..%2fapp.go",
+		"%252e%252e%252fapp.go",
+		"..\\app.go",
+	} {
+		buffer := new(bytes.Buffer)
+		res := webutil.NewMockResponse(buffer)
+		req := webutil.NewMockRequest("GET", "/"+filePath)
+		result := action(NewCtx(res, req, OptCtxRouteParams(RouteParameters{
+			RouteTokenFilepath: filePath,
+		}), OptCtxDefaultProvider(Text)))
+		assert.NotNil(result, filePath)
+		typed, ok := result.(*RawResult)
+		assert.True(ok, filePath)
+		assert.Equal(http.StatusBadRequest, typed.StatusCode, filePath)
+	}
+}
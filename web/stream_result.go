@@ -0,0 +1,73 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/webutil"
+)
+
+// Stream returns a new stream result.
+//
+// The stream function is called with the response, which is flushed
+// after each write, making it suitable for server sent events or long polling.
+func Stream(stream func(w http.ResponseWriter) error) *StreamResult {
+	return &StreamResult{
+		Stream: stream,
+	}
+}
+
+// StreamResult is a result that writes to the response incrementally.
+type StreamResult struct {
+	// StatusCode is an optional status code to write before streaming.
+	// If unset, the stream function may set headers and the status code itself.
+	StatusCode int
+	// ContentType is an optional content type to set before streaming.
+	ContentType string
+	// Stream is the function that writes to the response.
+	Stream func(http.ResponseWriter) error
+}
+
+// Render renders the result.
+func (sr *StreamResult) Render(ctx *Ctx) error {
+	if sr.Stream == nil {
+		return ex.New("stream result function is unset")
+	}
+	if len(sr.ContentType) != 0 {
+		ctx.Response.Header().Set(webutil.HeaderContentType, sr.ContentType)
+	}
+	if sr.StatusCode != 0 {
+		ctx.Response.WriteHeader(sr.StatusCode)
+	}
+	if err := sr.Stream(flushingResponseWriter{ctx.Response}); err != nil {
+		if typed, ok := err.(*net.OpError); ok {
+			return ex.New(webutil.ErrNetWrite, ex.OptInner(typed))
+		}
+		return ex.New(err)
+	}
+	ctx.Response.Flush()
+	return nil
+}
+
+// flushingResponseWriter flushes the response after each write.
+type flushingResponseWriter struct {
+	ResponseWriter
+}
+
+// Write writes the contents to the response and flushes it.
+func (frw flushingResponseWriter) Write(contents []byte) (n int, err error) {
+	n, err = frw.ResponseWriter.Write(contents)
+	if err != nil {
+		return
+	}
+	frw.ResponseWriter.Flush()
+	return
+}
@@ -0,0 +1,85 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/webutil"
+)
+
+type flushCountingResponse struct {
+	*webutil.MockResponseWriter
+	flushes int
+}
+
+func (fcr *flushCountingResponse) Flush() {
+	fcr.flushes++
+}
+
+func TestStreamResult(t *testing.T) {
+	assert := assert.New(t)
+
+	resBody := new(bytes.Buffer)
+	res := &flushCountingResponse{MockResponseWriter: webutil.NewMockResponse(resBody)}
+	ctx := NewCtx(res, webutil.NewMockRequest("GET", "/"))
+
+	result := Stream(func(w http.ResponseWriter) error {
+		w.Header().Set(webutil.HeaderContentType, "text/event-stream")
+		w.WriteHeader(http.StatusAccepted)
+		for x := 0; x < 3; x++ {
+			if _, err := fmt.Fprintf(w, "data: %d\n\n", x); err != nil {
+				return err
+			}
+			assert.Equal(x+1, res.flushes)
+		}
+		return nil
+	})
+	assert.Nil(result.Render(ctx))
+	assert.Equal(http.StatusAccepted, res.StatusCode())
+	assert.Equal("text/event-stream", res.Header().Get(webutil.HeaderContentType))
+	assert.Equal("data: 0\n\ndata: 1\n\ndata: 2\n\n", resBody.String())
+	assert.Equal(4, res.flushes)
+}
+
+func TestStreamResultError(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := NewCtx(webutil.NewMockResponse(new(bytes.Buffer)), webutil.NewMockRequest("GET", "/"))
+	err := Stream(func(_ http.ResponseWriter) error {
+		return fmt.Errorf("this is only a test")
+	}).Render(ctx)
+	assert.Equal("this is only a test", ex.ErrClass(err).Error())
+
+	assert.NotNil((&StreamResult{}).Render(ctx))
+}
+
+func TestStreamResultApp(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/", func(_ *Ctx) Result {
+		return &StreamResult{
+			StatusCode:  http.StatusOK,
+			ContentType: webutil.ContentTypeText,
+			Stream: func(w http.ResponseWriter) error {
+				_, err := w.Write([]byte("OK!"))
+				return err
+			},
+		}
+	})
+	contents, meta, err := MockGet(app, "/").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal("OK!", string(contents))
+}
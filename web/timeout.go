@@ -10,10 +10,17 @@ package web
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 )
 
 // WithTimeout injects the context for a given action with a timeout context.
+//
+// If the action does not return before the timeout elapses, a 503 is rendered
+// with the default provider and any further writes the action makes to the
+// response are discarded, returning `http.ErrHandlerTimeout`. If the action
+// has already written the response header when the timeout elapses, the 503
+// cannot be sent; the response is cut short instead.
 func WithTimeout(d time.Duration) Middleware {
 	return func(action Action) Action {
 		return func(r *Ctx) Result {
@@ -22,6 +29,9 @@ func WithTimeout(d time.Duration) Middleware {
 
 			r.Request = r.Request.WithContext(ctx)
 
+			tw := newTimeoutResponseWriter(r.Response)
+			r.Response = tw
+
 			panicChan := make(chan interface{}, 1)
 			resultChan := make(chan Result, 1)
 
@@ -40,8 +50,164 @@ func WithTimeout(d time.Duration) Middleware {
 			case res := <-resultChan:
 				return res
 			case <-ctx.Done():
-				return r.DefaultProvider.Status(http.StatusServiceUnavailable, nil)
+				if !tw.timeout() {
+					tw.finish()
+					return nil
+				}
+				return &timeoutResult{
+					Response: tw,
+					Result:   r.DefaultProvider.Status(http.StatusServiceUnavailable, nil),
+				}
 			}
 		}
 	}
 }
+
+// timeoutResult renders a result straight to the underlying response
+// so that it is not discarded alongside the timed out action's writes.
+type timeoutResult struct {
+	Response *timeoutResponseWriter
+	Result   Result
+}
+
+// Render renders the result.
+func (tr *timeoutResult) Render(ctx *Ctx) error {
+	tr.Response.mu.Lock()
+	defer tr.Response.mu.Unlock()
+	defer func() { tr.Response.finished = true }()
+
+	timeoutCtx := *ctx
+	timeoutCtx.Response = tr.Response.inner
+	return tr.Result.Render(&timeoutCtx)
+}
+
+func newTimeoutResponseWriter(w ResponseWriter) *timeoutResponseWriter {
+	return &timeoutResponseWriter{
+		inner:  w,
+		header: w.Header().Clone(),
+	}
+}
+
+// timeoutResponseWriter guards a response against writes after a timeout.
+//
+// Headers are staged on a separate map until the status code is written so that
+// the action's goroutine never touches the underlying response's headers while
+// the timeout result is being rendered. Once timed out, callers are handed
+// a copy of the underlying response's headers.
+//
+// The underlying response is not embedded, so that every method is guarded,
+// and it is never handed out, so the action cannot write to it after the timeout.
+type timeoutResponseWriter struct {
+	inner ResponseWriter
+
+	mu          sync.Mutex
+	header      http.Header
+	wroteHeader bool
+	timedOut    bool
+	// finished is set once the timeout response (if any) has been written.
+	finished bool
+	closed   bool
+}
+
+// finish marks the timeout response as written, after which the response can be closed.
+func (tw *timeoutResponseWriter) finish() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.finished = true
+}
+
+// timeout marks the response as timed out, and returns
+// if the timeout result can still be written.
+func (tw *timeoutResponseWriter) timeout() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.timedOut = true
+	return !tw.wroteHeader
+}
+
+// Header returns the staged response headers.
+func (tw *timeoutResponseWriter) Header() http.Header {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return tw.inner.Header().Clone()
+	}
+	return tw.header
+}
+
+// WriteHeader writes the staged headers and the status code.
+func (tw *timeoutResponseWriter) WriteHeader(statusCode int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.writeHeaderUnsafe(statusCode)
+}
+
+// Write writes to the response unless the timeout has elapsed.
+func (tw *timeoutResponseWriter) Write(contents []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.writeHeaderUnsafe(http.StatusOK)
+	}
+	return tw.inner.Write(contents)
+}
+
+// Flush flushes the response unless the timeout has elapsed.
+func (tw *timeoutResponseWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+	tw.inner.Flush()
+}
+
+// StatusCode returns the status code of the underlying response.
+func (tw *timeoutResponseWriter) StatusCode() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.inner.StatusCode()
+}
+
+// ContentLength returns the content length of the underlying response.
+func (tw *timeoutResponseWriter) ContentLength() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.inner.ContentLength()
+}
+
+// InnerResponse returns the guarded response itself, rather than the underlying
+// response, so that writes made through it are still discarded after the timeout.
+func (tw *timeoutResponseWriter) InnerResponse() http.ResponseWriter {
+	return tw
+}
+
+// Close closes the underlying response once.
+//
+// After the timeout, calls made before the timeout response has been written
+// (e.g. by the timed out action) are ignored; the request closes the response
+// once it's done.
+func (tw *timeoutResponseWriter) Close() error {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.closed || (tw.timedOut && !tw.finished) {
+		return nil
+	}
+	tw.closed = true
+	return tw.inner.Close()
+}
+
+func (tw *timeoutResponseWriter) writeHeaderUnsafe(statusCode int) {
+	header := tw.inner.Header()
+	for key, values := range tw.header {
+		header[key] = values
+	}
+	tw.wroteHeader = true
+	tw.inner.WriteHeader(statusCode)
+}
@@ -57,3 +57,76 @@ func TestTimeout(t *testing.T) {
 	assert.Nil(res.Body.Close())
 	assert.Equal(1, atomic.LoadInt32(&didShortFinish))
 }
+
+func TestTimeoutWritesAfterTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(WithTimeout(time.Millisecond)))
+
+	writeErrs := make(chan error, 1)
+	app.GET("/long", func(r *Ctx) Result {
+		<-r.Context().Done()
+		// wait for the timeout result to be rendered
+		time.Sleep(5 * time.Millisecond)
+		r.Response.Header().Set("X-Late", "true")
+		r.Response.WriteHeader(http.StatusOK)
+		_, err := r.Response.Write([]byte("late"))
+		writeErrs <- err
+		return Text.Result("late")
+	})
+	app.GET("/short", func(r *Ctx) Result {
+		return Text.Result("OK!")
+	})
+
+	contents, meta, err := MockGet(app, "/long").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusServiceUnavailable, meta.StatusCode)
+	assert.Empty(meta.Header.Get("X-Late"))
+	assert.NotContains(string(contents), "late")
+	assert.Equal(http.ErrHandlerTimeout, <-writeErrs)
+
+	contents, meta, err = MockGet(app, "/short").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+	assert.Equal("OK!", string(contents))
+}
+
+func TestTimeoutInnerResponseAfterTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(WithTimeout(time.Millisecond)))
+
+	writeErrs := make(chan error, 2)
+	app.GET("/long", func(r *Ctx) Result {
+		<-r.Context().Done()
+		writeErrs <- r.Response.Close()
+		// wait for the timeout result to be rendered
+		time.Sleep(5 * time.Millisecond)
+		_, err := r.Response.InnerResponse().Write([]byte("late"))
+		writeErrs <- err
+		return nil
+	})
+
+	contents, meta, err := MockGet(app, "/long").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusServiceUnavailable, meta.StatusCode)
+	assert.NotEmpty(contents)
+	assert.NotContains(string(contents), "late")
+	assert.Nil(<-writeErrs)
+	assert.Equal(http.ErrHandlerTimeout, <-writeErrs)
+}
+
+func TestTimeoutHeaderAlreadyWritten(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(WithTimeout(time.Millisecond)))
+	app.GET("/partial", func(r *Ctx) Result {
+		r.Response.WriteHeader(http.StatusAccepted)
+		<-r.Context().Done()
+		return nil
+	})
+
+	_, meta, err := MockGet(app, "/partial").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusAccepted, meta.StatusCode)
+}
@@ -0,0 +1,222 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	_ ResponseWriter      = (*CompressionResponseWriter)(nil)
+	_ http.ResponseWriter = (*CompressionResponseWriter)(nil)
+	_ http.Flusher        = (*CompressionResponseWriter)(nil)
+	_ io.Closer           = (*CompressionResponseWriter)(nil)
+)
+
+// CompressionOptions are options for the compression response writer.
+type CompressionOptions struct {
+	// MinSize is the minimum response body size in bytes that will be compressed.
+	MinSize int
+	// Level is the compression level, e.g. `gzip.BestSpeed`.
+	Level int
+	// ContentTypes are the content type prefixes that will be compressed.
+	ContentTypes []string
+}
+
+// NewCompressionResponseWriter returns a new response writer that compresses
+// output with a given content encoding, either `gzip` or `deflate`.
+//
+// Unlike `NewGZipResponseWriter`, it wraps the given response rather than its
+// inner response, and only compresses the response if its content type is
+// compressible, it doesn't already have a content encoding, and the body is
+// at least `MinSize` bytes.
+func NewCompressionResponseWriter(w http.ResponseWriter, encoding string, options CompressionOptions) *CompressionResponseWriter {
+	return &CompressionResponseWriter{
+		innerResponse: w,
+		options:       options,
+		encoding:      encoding,
+	}
+}
+
+// CompressionResponseWriter buffers the start of a response until it can decide
+// if the response should be compressed, and then compresses or passes through the rest.
+type CompressionResponseWriter struct {
+	innerResponse http.ResponseWriter
+
+	options  CompressionOptions
+	encoding string
+
+	buffer        bytes.Buffer
+	decided       bool
+	encoder       io.WriteCloser
+	statusCode    int
+	contentLength int
+}
+
+// InnerResponse returns the underlying response.
+func (crw *CompressionResponseWriter) InnerResponse() http.ResponseWriter {
+	return crw.innerResponse
+}
+
+// Header returns the headers for the response.
+func (crw *CompressionResponseWriter) Header() http.Header {
+	return crw.innerResponse.Header()
+}
+
+// WriteHeader stages a status code until the response is decided.
+func (crw *CompressionResponseWriter) WriteHeader(statusCode int) {
+	if crw.statusCode != 0 {
+		return
+	}
+	crw.statusCode = statusCode
+	if !BodyAllowedForStatus(statusCode) {
+		_ = crw.decide(false)
+	}
+}
+
+// Write writes the uncompressed bytes to the response.
+func (crw *CompressionResponseWriter) Write(contents []byte) (int, error) {
+	crw.contentLength += len(contents)
+	if !crw.decided {
+		crw.buffer.Write(contents)
+		if crw.buffer.Len() < crw.options.MinSize {
+			return len(contents), nil
+		}
+		if err := crw.decide(true); err != nil {
+			return 0, err
+		}
+		return len(contents), nil
+	}
+	if crw.encoder != nil {
+		return crw.encoder.Write(contents)
+	}
+	return crw.innerResponse.Write(contents)
+}
+
+// Flush writes any buffered output to the response and flushes it.
+func (crw *CompressionResponseWriter) Flush() {
+	if !crw.decided {
+		_ = crw.decide(true)
+	}
+	if typed, ok := crw.encoder.(interface{ Flush() error }); ok {
+		_ = typed.Flush()
+	}
+	if typed, ok := crw.innerResponse.(http.Flusher); ok {
+		typed.Flush()
+	}
+}
+
+// Close writes any buffered output and closes the compressor and the inner response
+// if it supports it.
+func (crw *CompressionResponseWriter) Close() error {
+	if !crw.decided {
+		if err := crw.decide(crw.buffer.Len() >= crw.options.MinSize); err != nil {
+			return err
+		}
+	}
+	if crw.encoder != nil {
+		if err := crw.encoder.Close(); err != nil {
+			return err
+		}
+	}
+	if typed, ok := crw.innerResponse.(io.Closer); ok {
+		return typed.Close()
+	}
+	return nil
+}
+
+// StatusCode returns the status code for the response.
+func (crw *CompressionResponseWriter) StatusCode() int {
+	if crw.statusCode != 0 {
+		return crw.statusCode
+	}
+	if typed, ok := crw.innerResponse.(ResponseWriter); ok {
+		return typed.StatusCode()
+	}
+	return http.StatusOK
+}
+
+// ContentLength returns the uncompressed content length for the response.
+func (crw *CompressionResponseWriter) ContentLength() int {
+	return crw.contentLength
+}
+
+// decide writes the response headers, compressing the response if it's
+// allowed and compressible, and then writes any buffered output.
+func (crw *CompressionResponseWriter) decide(allowed bool) error {
+	crw.decided = true
+
+	header := crw.innerResponse.Header()
+	HeaderAddVary(header, HeaderAcceptEncoding)
+	if header.Get(HeaderContentType) == "" && crw.buffer.Len() > 0 {
+		header.Set(HeaderContentType, http.DetectContentType(crw.buffer.Bytes()))
+	}
+	if allowed && crw.compressible(header) {
+		header.Set(HeaderContentEncoding, crw.encoding)
+		header.Del(HeaderContentLength)
+
+		var err error
+		switch crw.encoding {
+		case ContentEncodingDeflate:
+			crw.encoder, err = zlib.NewWriterLevel(crw.innerResponse, crw.options.Level)
+		default:
+			crw.encoder, err = gzip.NewWriterLevel(crw.innerResponse, crw.options.Level)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if crw.statusCode != 0 {
+		crw.innerResponse.WriteHeader(crw.statusCode)
+	}
+	if crw.buffer.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if crw.encoder != nil {
+		_, err = crw.encoder.Write(crw.buffer.Bytes())
+	} else {
+		_, err = crw.innerResponse.Write(crw.buffer.Bytes())
+	}
+	crw.buffer.Reset()
+	return err
+}
+
+// compressible returns if the response should be compressed based on its headers.
+func (crw *CompressionResponseWriter) compressible(header http.Header) bool {
+	if !BodyAllowedForStatus(crw.statusCode) {
+		return false
+	}
+	if contentEncoding := header.Get(HeaderContentEncoding); contentEncoding != "" && contentEncoding != ContentEncodingIdentity {
+		return false
+	}
+	contentType := strings.ToLower(header.Get(HeaderContentType))
+	for _, prefix := range crw.options.ContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// BodyAllowedForStatus returns if a given status code permits a response body.
+func BodyAllowedForStatus(statusCode int) bool {
+	switch {
+	case statusCode >= 100 && statusCode <= 199:
+		return false
+	case statusCode == http.StatusNoContent, statusCode == http.StatusNotModified:
+		return false
+	}
+	return true
+}
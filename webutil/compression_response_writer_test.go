@@ -0,0 +1,84 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestCompressionResponseWriterFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set(HeaderContentType, ContentTypeText)
+	crw := NewCompressionResponseWriter(NewStatusResponseWriter(recorder), ContentEncodingGZIP, CompressionOptions{
+		MinSize:      1024,
+		Level:        gzip.DefaultCompression,
+		ContentTypes: []string{"text/"},
+	})
+
+	_, err := crw.Write([]byte("data: hello\n\n"))
+	assert.Nil(err)
+	assert.False(recorder.Flushed)
+
+	crw.Flush()
+	assert.True(recorder.Flushed)
+	assert.Equal(ContentEncodingGZIP, recorder.Header().Get(HeaderContentEncoding))
+
+	decompressor, err := gzip.NewReader(bytes.NewReader(recorder.Body.Bytes()))
+	assert.Nil(err)
+	chunk := make([]byte, len("data: hello\n\n"))
+	_, err = io.ReadFull(decompressor, chunk)
+	assert.Nil(err)
+	assert.Equal("data: hello\n\n", string(chunk))
+
+	assert.Nil(crw.Close())
+	assert.Equal(len("data: hello\n\n"), crw.ContentLength())
+	assert.Equal(http.StatusOK, crw.StatusCode())
+}
+
+func TestCompressionResponseWriterPassthrough(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set(HeaderContentType, ContentTypeText)
+	crw := NewCompressionResponseWriter(recorder, ContentEncodingGZIP, CompressionOptions{
+		MinSize:      1024,
+		Level:        gzip.DefaultCompression,
+		ContentTypes: []string{"text/"},
+	})
+	assert.Equal(recorder, crw.InnerResponse())
+
+	crw.WriteHeader(http.StatusAccepted)
+	_, err := crw.Write([]byte("small"))
+	assert.Nil(err)
+	assert.Nil(crw.Close())
+
+	assert.Equal(http.StatusAccepted, recorder.Code)
+	assert.Empty(recorder.Header().Get(HeaderContentEncoding))
+	assert.Equal(HeaderAcceptEncoding, recorder.Header().Get(HeaderVary))
+	assert.Equal("small", recorder.Body.String())
+	assert.Equal(http.StatusAccepted, crw.StatusCode())
+}
+
+func TestBodyAllowedForStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(BodyAllowedForStatus(http.StatusOK))
+	assert.True(BodyAllowedForStatus(http.StatusNotFound))
+	assert.False(BodyAllowedForStatus(http.StatusContinue))
+	assert.False(BodyAllowedForStatus(http.StatusNoContent))
+	assert.False(BodyAllowedForStatus(http.StatusNotModified))
+}
@@ -45,34 +45,47 @@ const (
 
 // Header names in canonical form.
 var (
-	HeaderAccept                  = http.CanonicalHeaderKey("Accept")
-	HeaderAcceptEncoding          = http.CanonicalHeaderKey("Accept-Encoding")
-	HeaderAllow                   = http.CanonicalHeaderKey("Allow")
-	HeaderAuthorization           = http.CanonicalHeaderKey("Authorization")
-	HeaderCacheControl            = http.CanonicalHeaderKey("Cache-Control")
-	HeaderConnection              = http.CanonicalHeaderKey("Connection")
-	HeaderContentEncoding         = http.CanonicalHeaderKey("Content-Encoding")
-	HeaderContentLength           = http.CanonicalHeaderKey("Content-Length")
-	HeaderContentType             = http.CanonicalHeaderKey("Content-Type")
-	HeaderCookie                  = http.CanonicalHeaderKey("Cookie")
-	HeaderDate                    = http.CanonicalHeaderKey("Date")
-	HeaderETag                    = http.CanonicalHeaderKey("etag")
-	HeaderForwarded               = http.CanonicalHeaderKey("Forwarded")
-	HeaderServer                  = http.CanonicalHeaderKey("Server")
-	HeaderSetCookie               = http.CanonicalHeaderKey("Set-Cookie")
-	HeaderStrictTransportSecurity = http.CanonicalHeaderKey("Strict-Transport-Security")
-	HeaderUserAgent               = http.CanonicalHeaderKey("User-Agent")
-	HeaderVary                    = http.CanonicalHeaderKey("Vary")
-	HeaderXContentTypeOptions     = http.CanonicalHeaderKey("X-Content-Type-Options")
-	HeaderXForwardedFor           = http.CanonicalHeaderKey("X-Forwarded-For")
-	HeaderXForwardedHost          = http.CanonicalHeaderKey("X-Forwarded-Host")
-	HeaderXForwardedPort          = http.CanonicalHeaderKey("X-Forwarded-Port")
-	HeaderXForwardedProto         = http.CanonicalHeaderKey("X-Forwarded-Proto")
-	HeaderXForwardedScheme        = http.CanonicalHeaderKey("X-Forwarded-Scheme")
-	HeaderXFrameOptions           = http.CanonicalHeaderKey("X-Frame-Options")
-	HeaderXRealIP                 = http.CanonicalHeaderKey("X-Real-IP")
-	HeaderXServedBy               = http.CanonicalHeaderKey("X-Served-By")
-	HeaderXXSSProtection          = http.CanonicalHeaderKey("X-Xss-Protection")
+	HeaderAccept                        = http.CanonicalHeaderKey("Accept")
+	HeaderAcceptEncoding                = http.CanonicalHeaderKey("Accept-Encoding")
+	HeaderAccessControlAllowCredentials = http.CanonicalHeaderKey("Access-Control-Allow-Credentials")
+	HeaderAccessControlAllowHeaders     = http.CanonicalHeaderKey("Access-Control-Allow-Headers")
+	HeaderAccessControlAllowMethods     = http.CanonicalHeaderKey("Access-Control-Allow-Methods")
+	HeaderAccessControlAllowOrigin      = http.CanonicalHeaderKey("Access-Control-Allow-Origin")
+	HeaderAccessControlExposeHeaders    = http.CanonicalHeaderKey("Access-Control-Expose-Headers")
+	HeaderAccessControlMaxAge           = http.CanonicalHeaderKey("Access-Control-Max-Age")
+	HeaderAccessControlRequestHeaders   = http.CanonicalHeaderKey("Access-Control-Request-Headers")
+	HeaderAccessControlRequestMethod    = http.CanonicalHeaderKey("Access-Control-Request-Method")
+	HeaderAllow                         = http.CanonicalHeaderKey("Allow")
+	HeaderAuthorization                 = http.CanonicalHeaderKey("Authorization")
+	HeaderCacheControl                  = http.CanonicalHeaderKey("Cache-Control")
+	HeaderConnection                    = http.CanonicalHeaderKey("Connection")
+	HeaderContentEncoding               = http.CanonicalHeaderKey("Content-Encoding")
+	HeaderContentLength                 = http.CanonicalHeaderKey("Content-Length")
+	HeaderContentType                   = http.CanonicalHeaderKey("Content-Type")
+	HeaderCookie                        = http.CanonicalHeaderKey("Cookie")
+	HeaderDate                          = http.CanonicalHeaderKey("Date")
+	HeaderETag                          = http.CanonicalHeaderKey("etag")
+	HeaderForwarded                     = http.CanonicalHeaderKey("Forwarded")
+	HeaderIfModifiedSince               = http.CanonicalHeaderKey("If-Modified-Since")
+	HeaderIfNoneMatch                   = http.CanonicalHeaderKey("If-None-Match")
+	HeaderLastModified                  = http.CanonicalHeaderKey("Last-Modified")
+	HeaderOrigin                        = http.CanonicalHeaderKey("Origin")
+	HeaderRetryAfter                    = http.CanonicalHeaderKey("Retry-After")
+	HeaderServer                        = http.CanonicalHeaderKey("Server")
+	HeaderSetCookie                     = http.CanonicalHeaderKey("Set-Cookie")
+	HeaderStrictTransportSecurity       = http.CanonicalHeaderKey("Strict-Transport-Security")
+	HeaderUserAgent                     = http.CanonicalHeaderKey("User-Agent")
+	HeaderVary                          = http.CanonicalHeaderKey("Vary")
+	HeaderXContentTypeOptions           = http.CanonicalHeaderKey("X-Content-Type-Options")
+	HeaderXForwardedFor                 = http.CanonicalHeaderKey("X-Forwarded-For")
+	HeaderXForwardedHost                = http.CanonicalHeaderKey("X-Forwarded-Host")
+	HeaderXForwardedPort                = http.CanonicalHeaderKey("X-Forwarded-Port")
+	HeaderXForwardedProto               = http.CanonicalHeaderKey("X-Forwarded-Proto")
+	HeaderXForwardedScheme              = http.CanonicalHeaderKey("X-Forwarded-Scheme")
+	HeaderXFrameOptions                 = http.CanonicalHeaderKey("X-Frame-Options")
+	HeaderXRealIP                       = http.CanonicalHeaderKey("X-Real-IP")
+	HeaderXServedBy                     = http.CanonicalHeaderKey("X-Served-By")
+	HeaderXXSSProtection                = http.CanonicalHeaderKey("X-Xss-Protection")
 )
 
 /*
@@ -151,6 +164,9 @@ const (
 	// ContentEncodingGZIP is the gzip (compressed) content encoding.
 	ContentEncodingGZIP = "gzip"
 
+	// ContentEncodingDeflate is the deflate (compressed) content encoding.
+	ContentEncodingDeflate = "deflate"
+
 	// ConnectionClose is the connection value of "close"
 	ConnectionClose = "close"
 )
@@ -15,6 +15,8 @@ const (
 	ErrParameterMissing       ex.Class = "parameter missing"
 	ErrUnauthorized           ex.Class = "unauthorized"
 	ErrInvalidSplitColonInput ex.Class = `split colon input string is not of the form "<first>:<second>"`
+	ErrMultipartFileTooLarge  ex.Class = "multipart file exceeds the max size"
+	ErrMultipartFieldNotFile  ex.Class = "multipart field is not a file"
 )
 
 // ErrIsInvalidSameSite returns if an error is `ErrInvalidSameSite`
@@ -36,3 +38,13 @@ func ErrIsUnauthorized(err error) bool {
 func ErrIsInvalidSplitColonInput(err error) bool {
 	return ex.Is(err, ErrInvalidSplitColonInput)
 }
+
+// ErrIsMultipartFileTooLarge returns if an error is `ErrMultipartFileTooLarge`
+func ErrIsMultipartFileTooLarge(err error) bool {
+	return ex.Is(err, ErrMultipartFileTooLarge)
+}
+
+// ErrIsMultipartFieldNotFile returns if an error is `ErrMultipartFieldNotFile`
+func ErrIsMultipartFieldNotFile(err error) bool {
+	return ex.Is(err, ErrMultipartFieldNotFile)
+}
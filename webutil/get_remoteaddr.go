@@ -12,20 +12,71 @@ package webutil
 import (
 	"net"
 	"net/http"
+	"strings"
 )
 
+// RemoteAddrOptions are options for `GetRemoteAddr`.
+type RemoteAddrOptions struct {
+	// LeftMost selects the left-most (i.e. the original client) entry of
+	// forwarding headers, instead of the right-most entry added by the nearest proxy.
+	LeftMost bool
+	// TrustForwarded enables checking the `Forwarded` header before `X-Forwarded-For`.
+	TrustForwarded bool
+}
+
+// RemoteAddrOption mutates remote addr options.
+type RemoteAddrOption func(*RemoteAddrOptions)
+
+// OptRemoteAddrLeftMost sets if the left-most entry of forwarding headers should be used.
+//
+// The left-most entry is set by the client, and can be spoofed unless every
+// proxy in front of the service overwrites the header.
+func OptRemoteAddrLeftMost(leftMost bool) RemoteAddrOption {
+	return func(rao *RemoteAddrOptions) {
+		rao.LeftMost = leftMost
+	}
+}
+
+// OptRemoteAddrTrustForwarded sets if the `for=` directive of the `Forwarded` header should be checked
+// before `X-Forwarded-For`.
+//
+// Only enable this if every proxy in front of the service sets (or strips) the `Forwarded` header;
+// many proxies only set `X-Forwarded-For`, and pass a `Forwarded` header set by the client through.
+func OptRemoteAddrTrustForwarded(trustForwarded bool) RemoteAddrOption {
+	return func(rao *RemoteAddrOptions) {
+		rao.TrustForwarded = trustForwarded
+	}
+}
+
 // GetRemoteAddr gets the origin/client ip for a request.
-// X-FORWARDED-FOR is checked. If multiple IPs are included the first one is returned
-// X-REAL-IP is checked. If multiple IPs are included the last one is returned
-// Finally r.RemoteAddr is used
+// FORWARDED is checked for a `for=` directive if enabled with `OptRemoteAddrTrustForwarded`.
+// X-FORWARDED-FOR is checked. If multiple IPs are included the last one is returned.
+// X-REAL-IP is checked. If multiple IPs are included the last one is returned.
+// Finally r.RemoteAddr is used.
+// The left-most entry of the headers can be used instead with `OptRemoteAddrLeftMost`.
 // Only benevolent services will allow access to the real IP.
-func GetRemoteAddr(r *http.Request) string {
+func GetRemoteAddr(r *http.Request, opts ...RemoteAddrOption) string {
 	if r == nil {
 		return ""
 	}
+	var options RemoteAddrOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	tryHeader := func(key string) (string, bool) {
+		if options.LeftMost {
+			return HeaderFirstValue(r.Header, key)
+		}
 		return HeaderLastValue(r.Header, key)
 	}
+
+	if options.TrustForwarded {
+		if forwarded, ok := tryHeader(HeaderForwarded); ok {
+			if forwardedFor, ok := forwardedForValue(forwarded); ok {
+				return forwardedFor
+			}
+		}
+	}
 	for _, header := range []string{HeaderXForwardedFor, HeaderXRealIP} {
 		if headerVal, ok := tryHeader(header); ok {
 			return headerVal
@@ -34,3 +85,28 @@ func GetRemoteAddr(r *http.Request) string {
 	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
 	return ip
 }
+
+// forwardedForValue returns the address of the `for=` directive of
+// a single `Forwarded` header element, e.g. `for=192.0.2.60;proto=http`.
+func forwardedForValue(element string) (string, bool) {
+	for _, pair := range strings.Split(element, ";") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "for") {
+			continue
+		}
+		value := strings.Trim(strings.TrimSpace(parts[1]), `"`)
+		if value == "" || strings.EqualFold(value, "unknown") {
+			return "", false
+		}
+		return stripAddrPort(value), true
+	}
+	return "", false
+}
+
+// stripAddrPort removes the port and ipv6 brackets from an address if present.
+func stripAddrPort(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+}
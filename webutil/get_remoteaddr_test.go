@@ -69,3 +69,44 @@ func TestGetRemoteAddr(t *testing.T) {
 	}
 	assert.Equal("", GetRemoteAddr(&r))
 }
+
+func TestGetRemoteAddrForwarded(t *testing.T) {
+	its := assert.New(t)
+
+	testCases := [...]struct {
+		Forwarded      string
+		XForwardedFor  string
+		LeftMost       bool
+		TrustForwarded bool
+		Expected       string
+	}{
+		{Forwarded: "for=192.0.2.60;proto=http;by=203.0.113.43", TrustForwarded: true, Expected: "192.0.2.60"},
+		{Forwarded: "For=192.0.2.60:4711", TrustForwarded: true, Expected: "192.0.2.60"},
+		{Forwarded: `for="[2001:db8:cafe::17]"`, TrustForwarded: true, Expected: "2001:db8:cafe::17"},
+		{Forwarded: `for="[2001:db8:cafe::17]:4711"`, TrustForwarded: true, Expected: "2001:db8:cafe::17"},
+		{Forwarded: `for=192.0.2.43, for="[2001:db8:cafe::17]"`, TrustForwarded: true, Expected: "2001:db8:cafe::17"},
+		{Forwarded: `for=192.0.2.43, for="[2001:db8:cafe::17]"`, TrustForwarded: true, LeftMost: true, Expected: "192.0.2.43"},
+		{Forwarded: "for=192.0.2.60", XForwardedFor: "10.0.0.1", TrustForwarded: true, Expected: "192.0.2.60"},
+		{Forwarded: "proto=https", XForwardedFor: "10.0.0.1", TrustForwarded: true, Expected: "10.0.0.1"},
+		{Forwarded: "for=unknown", XForwardedFor: "10.0.0.1", TrustForwarded: true, Expected: "10.0.0.1"},
+		// the forwarded header is ignored unless it's trusted.
+		{Forwarded: "for=192.0.2.60", XForwardedFor: "10.0.0.1", Expected: "10.0.0.1"},
+		{Forwarded: "for=192.0.2.60", Expected: "127.0.0.1"},
+		{XForwardedFor: "10.0.0.1, 10.0.0.2", Expected: "10.0.0.2"},
+		{XForwardedFor: "10.0.0.1, 10.0.0.2", LeftMost: true, Expected: "10.0.0.1"},
+		// x-forwarded-for values are returned as is.
+		{XForwardedFor: "10.0.0.1:8080", Expected: "10.0.0.1:8080"},
+	}
+
+	for _, tc := range testCases {
+		hdr := http.Header{}
+		if tc.Forwarded != "" {
+			hdr.Set(HeaderForwarded, tc.Forwarded)
+		}
+		if tc.XForwardedFor != "" {
+			hdr.Set(HeaderXForwardedFor, tc.XForwardedFor)
+		}
+		r := http.Request{Header: hdr, RemoteAddr: "127.0.0.1:1234"}
+		its.Equal(tc.Expected, GetRemoteAddr(&r, OptRemoteAddrLeftMost(tc.LeftMost), OptRemoteAddrTrustForwarded(tc.TrustForwarded)), tc.Forwarded+tc.XForwardedFor)
+	}
+}
@@ -24,6 +24,18 @@ func HeaderLastValue(headers http.Header, key string) (string, bool) {
 	return "", false
 }
 
+// HeaderFirstValue returns the first value of a potential csv of headers.
+func HeaderFirstValue(headers http.Header, key string) (string, bool) {
+	if rawHeaderValue := headers.Get(key); rawHeaderValue != "" {
+		if !strings.ContainsRune(rawHeaderValue, ',') {
+			return strings.TrimSpace(rawHeaderValue), true
+		}
+		vals := strings.Split(rawHeaderValue, ",")
+		return strings.TrimSpace(vals[0]), true
+	}
+	return "", false
+}
+
 // HeaderAny returns if any pieces of a header match a given value.
 func HeaderAny(headers http.Header, key, value string) bool {
 	if rawHeaderValue := headers.Get(key); rawHeaderValue != "" {
@@ -40,6 +52,18 @@ func HeaderAny(headers http.Header, key, value string) bool {
 	return false
 }
 
+// HeaderAddVary adds a value to the vary header if it isn't already present.
+func HeaderAddVary(header http.Header, value string) {
+	for _, existing := range header.Values(HeaderVary) {
+		for _, part := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), value) {
+				return
+			}
+		}
+	}
+	header.Add(HeaderVary, value)
+}
+
 // Headers creates headers from a given map.
 func Headers(from map[string]string) http.Header {
 	output := make(http.Header)
@@ -24,3 +24,29 @@ func TestHeaderAny(t *testing.T) {
 	assert.True(HeaderAny(http.Header{"Foo": []string{"bar,example-string"}}, "foo", "bar"))
 	assert.True(HeaderAny(http.Header{"fuzz": []string{"buzz"}, "Foo": []string{"bar,example-string"}}, "foo", "bar"))
 }
+
+func TestHeaderAddVary(t *testing.T) {
+	assert := assert.New(t)
+
+	header := http.Header{HeaderVary: []string{"Origin, accept-encoding"}}
+	HeaderAddVary(header, HeaderAcceptEncoding)
+	assert.Equal([]string{"Origin, accept-encoding"}, header.Values(HeaderVary))
+
+	HeaderAddVary(header, HeaderAccept)
+	assert.Equal([]string{"Origin, accept-encoding", HeaderAccept}, header.Values(HeaderVary))
+}
+
+func TestHeaderFirstValue(t *testing.T) {
+	assert := assert.New(t)
+
+	value, ok := HeaderFirstValue(http.Header{"Foo": []string{"bar, baz"}}, "foo")
+	assert.True(ok)
+	assert.Equal("bar", value)
+
+	value, ok = HeaderFirstValue(http.Header{"Foo": []string{" bar "}}, "foo")
+	assert.True(ok)
+	assert.Equal("bar", value)
+
+	_, ok = HeaderFirstValue(http.Header{}, "foo")
+	assert.False(ok)
+}
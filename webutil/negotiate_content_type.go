@@ -0,0 +1,117 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NegotiateContentType returns the offer that best matches the request `Accept` header.
+//
+// Media ranges are weighted by their `q` parameter, and the most specific range
+// matching an offer determines its weight, e.g. `application/json` over `application/*`
+// over `*/*`. Ties are broken by the order of offers. Ranges with malformed
+// `q` values are ignored.
+//
+// If the request has no `Accept` header the first offer is returned, and if
+// no offer is acceptable the default offer is returned.
+func NegotiateContentType(r *http.Request, offers []string, defaultOffer string) string {
+	if len(offers) == 0 {
+		return defaultOffer
+	}
+	if r == nil || r.Header.Get(HeaderAccept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAcceptHeader(strings.Join(r.Header.Values(HeaderAccept), ","))
+	bestOffer, bestQuality := defaultOffer, 0.0
+	for _, offer := range offers {
+		if quality := acceptQuality(ranges, offer); quality > bestQuality {
+			bestOffer, bestQuality = offer, quality
+		}
+	}
+	return bestOffer
+}
+
+// acceptRange is a parsed media range from an `Accept` header.
+type acceptRange struct {
+	Type    string
+	Subtype string
+	Quality float64
+}
+
+// specificity returns how specific the range is, or -1 if it does not match the media type.
+func (ar acceptRange) specificity(mediaType, mediaSubtype string) int {
+	switch {
+	case ar.Type == "*" && ar.Subtype == "*":
+		return 0
+	case ar.Type == mediaType && ar.Subtype == "*":
+		return 1
+	case ar.Type == mediaType && ar.Subtype == mediaSubtype:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// acceptQuality returns the quality of the most specific range that matches the offer.
+func acceptQuality(ranges []acceptRange, offer string) float64 {
+	mediaType, mediaSubtype, ok := splitMediaType(offer)
+	if !ok {
+		return 0
+	}
+	bestSpecificity, quality := -1, 0.0
+	for _, ar := range ranges {
+		if specificity := ar.specificity(mediaType, mediaSubtype); specificity > bestSpecificity {
+			bestSpecificity, quality = specificity, ar.Quality
+		}
+	}
+	return quality
+}
+
+// parseAcceptHeader parses the media ranges of an `Accept` header value.
+func parseAcceptHeader(header string) (output []acceptRange) {
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		mediaType, mediaSubtype, ok := splitMediaType(params[0])
+		if !ok {
+			continue
+		}
+		ar := acceptRange{Type: mediaType, Subtype: mediaSubtype, Quality: 1}
+		for _, param := range params[1:] {
+			pieces := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(pieces) != 2 || strings.ToLower(strings.TrimSpace(pieces[0])) != "q" {
+				continue
+			}
+			quality, err := strconv.ParseFloat(strings.TrimSpace(pieces[1]), 64)
+			if err != nil || quality < 0 || quality > 1 {
+				ok = false
+				break
+			}
+			ar.Quality = quality
+		}
+		if ok {
+			output = append(output, ar)
+		}
+	}
+	return
+}
+
+// splitMediaType splits a media type into its lower cased type and subtype, ignoring any parameters.
+func splitMediaType(mediaType string) (string, string, bool) {
+	if index := strings.IndexByte(mediaType, ';'); index >= 0 {
+		mediaType = mediaType[:index]
+	}
+	pieces := strings.SplitN(strings.ToLower(strings.TrimSpace(mediaType)), "/", 2)
+	if len(pieces) != 2 || pieces[0] == "" || pieces[1] == "" {
+		return "", "", false
+	}
+	return pieces[0], pieces[1], true
+}
@@ -0,0 +1,50 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	its := assert.New(t)
+
+	offers := []string{ContentTypeApplicationJSON, ContentTypeApplicationXML}
+
+	testCases := [...]struct {
+		Accept   string
+		Offers   []string
+		Expected string
+	}{
+		{Accept: "", Offers: offers, Expected: ContentTypeApplicationJSON},
+		{Accept: "application/xml", Offers: offers, Expected: ContentTypeApplicationXML},
+		{Accept: "application/json", Offers: offers, Expected: ContentTypeApplicationJSON},
+		{Accept: "*/*", Offers: offers, Expected: ContentTypeApplicationJSON},
+		{Accept: "application/*", Offers: offers, Expected: ContentTypeApplicationJSON},
+		{Accept: "application/json;q=0.5, application/xml", Offers: offers, Expected: ContentTypeApplicationXML},
+		{Accept: "application/*;q=0.2, application/xml;q=0.9", Offers: offers, Expected: ContentTypeApplicationXML},
+		{Accept: "application/json;q=0, */*;q=0.1", Offers: offers, Expected: ContentTypeApplicationXML},
+		{Accept: "text/html", Offers: offers, Expected: "text/plain"},
+		{Accept: "text/*", Offers: []string{ContentTypeHTML, ContentTypeText}, Expected: ContentTypeHTML},
+		{Accept: "application/xml;q=bogus, application/json;q=0.1", Offers: offers, Expected: ContentTypeApplicationJSON},
+		{Accept: "application/xml;q=2", Offers: offers, Expected: "text/plain"},
+		{Accept: "not-a-media-type, APPLICATION/XML", Offers: offers, Expected: ContentTypeApplicationXML},
+		{Accept: "application/xml", Offers: nil, Expected: "text/plain"},
+	}
+
+	for _, tc := range testCases {
+		req := &http.Request{Header: http.Header{}}
+		if tc.Accept != "" {
+			req.Header.Set(HeaderAccept, tc.Accept)
+		}
+		its.Equal(tc.Expected, NegotiateContentType(req, tc.Offers, "text/plain"), tc.Accept)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import "strings"
+
+// UserAgentInfo is the structured form of a user agent string.
+type UserAgentInfo struct {
+	// Browser is the browser (or bot) name, e.g. "Chrome" or "Googlebot".
+	Browser string
+	// Version is the browser (or bot) version, e.g. "91.0.4472.124".
+	Version string
+	// OS is the operating system name, e.g. "Windows" or "iOS".
+	OS string
+	// IsBot indicates the user agent is a known crawler or automated client.
+	IsBot bool
+}
+
+// ParseUserAgent parses a user agent string into structured fields.
+//
+// Parsing is heuristic; it matches product tokens against a small built-in
+// rule set covering the common browsers, operating systems and crawlers.
+// Fields that cannot be determined are left empty.
+func ParseUserAgent(ua string) (info UserAgentInfo) {
+	if ua == "" {
+		return
+	}
+	info.OS = parseUserAgentOS(ua)
+
+	lowered := strings.ToLower(ua)
+	for _, signature := range userAgentBotSignatures {
+		if index := strings.Index(lowered, signature); index >= 0 {
+			info.IsBot = true
+			info.Browser, info.Version = userAgentProductAt(ua, index)
+			return
+		}
+	}
+	for _, rule := range userAgentBrowserRules {
+		for _, token := range rule.Tokens {
+			if index := strings.Index(ua, token); index >= 0 {
+				info.Browser = rule.Name
+				if rule.VersionToken != "" {
+					if versionIndex := strings.Index(ua, rule.VersionToken); versionIndex >= 0 {
+						info.Version = userAgentVersionAt(ua, versionIndex+len(rule.VersionToken))
+					}
+				} else {
+					info.Version = userAgentVersionAt(ua, index+len(token))
+				}
+				return
+			}
+		}
+	}
+	return
+}
+
+// userAgentBotSignatures are lower cased substrings that identify crawlers and automated clients.
+var userAgentBotSignatures = []string{
+	"googlebot",
+	"bingbot",
+	"slurp",
+	"duckduckbot",
+	"baiduspider",
+	"yandexbot",
+	"facebookexternalhit",
+	"twitterbot",
+	"linkedinbot",
+	"slackbot",
+	"applebot",
+	"curl/",
+	"wget/",
+	"python-requests/",
+	"go-http-client/",
+	"headlesschrome/",
+	"bot",
+	"crawler",
+	"spider",
+}
+
+// userAgentBrowserRule matches a browser by product tokens, in order of precedence.
+type userAgentBrowserRule struct {
+	Name   string
+	Tokens []string
+	// VersionToken is an optional token the version follows, if it is not the matched token.
+	VersionToken string
+}
+
+// userAgentBrowserRules are ordered such that browsers that include
+// other browsers' tokens (e.g. Edge includes Chrome and Safari) match first.
+var userAgentBrowserRules = []userAgentBrowserRule{
+	{Name: "Edge", Tokens: []string{"Edg/", "EdgA/", "EdgiOS/", "Edge/"}},
+	{Name: "Opera", Tokens: []string{"OPR/", "Opera/"}},
+	{Name: "Samsung Internet", Tokens: []string{"SamsungBrowser/"}},
+	{Name: "Chrome", Tokens: []string{"CriOS/", "Chrome/"}},
+	{Name: "Firefox", Tokens: []string{"FxiOS/", "Firefox/"}},
+	{Name: "Safari", Tokens: []string{"Safari/"}, VersionToken: "Version/"},
+	{Name: "Internet Explorer", Tokens: []string{"MSIE "}},
+	{Name: "Internet Explorer", Tokens: []string{"Trident/"}, VersionToken: "rv:"},
+}
+
+// userAgentOSRules are ordered such that more specific platforms match first,
+// e.g. iOS user agents include "Mac OS X" and Android user agents include "Linux".
+var userAgentOSRules = []struct {
+	Name   string
+	Tokens []string
+}{
+	{Name: "Windows", Tokens: []string{"Windows"}},
+	{Name: "iOS", Tokens: []string{"iPhone", "iPad", "iPod"}},
+	{Name: "macOS", Tokens: []string{"Macintosh", "Mac OS X"}},
+	{Name: "Android", Tokens: []string{"Android"}},
+	{Name: "Chrome OS", Tokens: []string{"CrOS"}},
+	{Name: "Linux", Tokens: []string{"Linux"}},
+}
+
+func parseUserAgentOS(ua string) string {
+	for _, rule := range userAgentOSRules {
+		for _, token := range rule.Tokens {
+			if strings.Contains(ua, token) {
+				return rule.Name
+			}
+		}
+	}
+	return ""
+}
+
+// userAgentProductAt returns the product name and version of the `name/version`
+// token that contains a given index.
+func userAgentProductAt(ua string, index int) (name, version string) {
+	start := strings.LastIndexAny(ua[:index], " ;(+") + 1
+	end := len(ua)
+	if offset := strings.IndexAny(ua[start:], " ;)"); offset >= 0 {
+		end = start + offset
+	}
+	product := ua[start:end]
+	if slash := strings.IndexByte(product, '/'); slash >= 0 {
+		return product[:slash], product[slash+1:]
+	}
+	return product, ""
+}
+
+// userAgentVersionAt returns the version that starts at a given index.
+func userAgentVersionAt(ua string, index int) string {
+	end := len(ua)
+	if offset := strings.IndexAny(ua[index:], " ;)"); offset >= 0 {
+		end = index + offset
+	}
+	return ua[index:end]
+}
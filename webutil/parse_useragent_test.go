@@ -0,0 +1,68 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	its := assert.New(t)
+
+	testCases := [...]struct {
+		UserAgent string
+		Expected  UserAgentInfo
+	}{
+		{"", UserAgentInfo{}},
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
+			UserAgentInfo{Browser: "Chrome", Version: "91.0.4472.124", OS: "Windows"},
+		},
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36 Edg/91.0.864.59",
+			UserAgentInfo{Browser: "Edge", Version: "91.0.864.59", OS: "Windows"},
+		},
+		{
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.1 Safari/605.1.15",
+			UserAgentInfo{Browser: "Safari", Version: "14.1.1", OS: "macOS"},
+		},
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 14_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/91.0.4472.80 Mobile/15E148 Safari/604.1",
+			UserAgentInfo{Browser: "Chrome", Version: "91.0.4472.80", OS: "iOS"},
+		},
+		{
+			"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:89.0) Gecko/20100101 Firefox/89.0",
+			UserAgentInfo{Browser: "Firefox", Version: "89.0", OS: "Linux"},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 11; SM-G991B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/14.2 Chrome/87.0.4280.141 Mobile Safari/537.36",
+			UserAgentInfo{Browser: "Samsung Internet", Version: "14.2", OS: "Android"},
+		},
+		{
+			"Mozilla/5.0 (Windows NT 10.0; WOW64; Trident/7.0; rv:11.0) like Gecko",
+			UserAgentInfo{Browser: "Internet Explorer", Version: "11.0", OS: "Windows"},
+		},
+		{
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			UserAgentInfo{Browser: "Googlebot", Version: "2.1", IsBot: true},
+		},
+		{
+			"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm) Chrome/91.0.4472.114 Safari/537.36",
+			UserAgentInfo{Browser: "bingbot", Version: "2.0", IsBot: true},
+		},
+		{"curl/7.64.1", UserAgentInfo{Browser: "curl", Version: "7.64.1", IsBot: true}},
+		{"Go-http-client/1.1", UserAgentInfo{Browser: "Go-http-client", Version: "1.1", IsBot: true}},
+		{"something-unknown", UserAgentInfo{}},
+	}
+
+	for _, tc := range testCases {
+		its.Equal(tc.Expected, ParseUserAgent(tc.UserAgent), tc.UserAgent)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import (
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ReadMultipartFile reads a single file uploaded in a given multipart form field,
+// returning the file name and contents.
+//
+// The body is streamed part by part, so nothing is written to temporary files, and
+// reading stops as soon as the file exceeds `maxSize` bytes with `ErrMultipartFileTooLarge`.
+// If the form was already parsed with `ParseMultipartForm`, the parsed file is read instead.
+//
+// It returns `ErrParameterMissing` if the field is missing, and `ErrMultipartFieldNotFile`
+// if the field is a regular form value rather than a file.
+func ReadMultipartFile(r *http.Request, field string, maxSize int64) (filename string, data []byte, err error) {
+	if r.MultipartForm != nil {
+		return readParsedMultipartFile(r.MultipartForm, field, maxSize)
+	}
+
+	var reader *multipart.Reader
+	reader, err = r.MultipartReader()
+	if err != nil {
+		err = ex.New(err)
+		return
+	}
+	var part *multipart.Part
+	for {
+		part, err = reader.NextPart()
+		if err == io.EOF {
+			err = ex.New(ErrParameterMissing, ex.OptMessagef("multipart field: %s", field))
+			return
+		}
+		if err != nil {
+			err = ex.New(err)
+			return
+		}
+		if part.FormName() != field {
+			_ = part.Close()
+			continue
+		}
+		defer part.Close()
+		if part.FileName() == "" {
+			err = ex.New(ErrMultipartFieldNotFile, ex.OptMessagef("multipart field: %s", field))
+			return
+		}
+		filename = part.FileName()
+		data, err = readMultipartFileContents(part, field, maxSize)
+		return
+	}
+}
+
+// readParsedMultipartFile reads a file from an already parsed multipart form.
+func readParsedMultipartFile(form *multipart.Form, field string, maxSize int64) (filename string, data []byte, err error) {
+	headers := form.File[field]
+	if len(headers) == 0 {
+		if _, ok := form.Value[field]; ok {
+			err = ex.New(ErrMultipartFieldNotFile, ex.OptMessagef("multipart field: %s", field))
+			return
+		}
+		err = ex.New(ErrParameterMissing, ex.OptMessagef("multipart field: %s", field))
+		return
+	}
+	if headers[0].Size > maxSize {
+		err = ex.New(ErrMultipartFileTooLarge, ex.OptMessagef("multipart field: %s, max size: %d bytes", field, maxSize))
+		return
+	}
+	var file multipart.File
+	file, err = headers[0].Open()
+	if err != nil {
+		err = ex.New(err)
+		return
+	}
+	defer file.Close()
+	filename = headers[0].Filename
+	data, err = readMultipartFileContents(file, field, maxSize)
+	return
+}
+
+// readMultipartFileContents reads at most `maxSize` bytes from a file, erroring if there are more.
+func readMultipartFileContents(r io.Reader, field string, maxSize int64) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ex.New(ErrMultipartFileTooLarge, ex.OptMessagef("multipart field: %s, max size: %d bytes", field, maxSize))
+	}
+	return data, nil
+}
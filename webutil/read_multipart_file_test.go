@@ -0,0 +1,109 @@
+/*
+
+Copyright (c) 2021 - Present. Blend Labs, Inc. All rights reserved
+Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+
+*/
+
+package webutil
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func newMultipartFileRequest(its *assert.Assertions, field, filename, contents string) *http.Request {
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	_ = mw.WriteField("note", "not a file")
+	if field != "" {
+		w, err := mw.CreateFormFile(field, filename)
+		its.Nil(err)
+		_, err = w.Write([]byte(contents))
+		its.Nil(err)
+	}
+	its.Nil(mw.Close())
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set(HeaderContentType, mw.FormDataContentType())
+	return req
+}
+
+func TestReadMultipartFile(t *testing.T) {
+	its := assert.New(t)
+
+	req := newMultipartFileRequest(its, "file", "test.txt", "this is only a test")
+	filename, data, err := ReadMultipartFile(req, "file", 1024)
+	its.Nil(err)
+	its.Equal("test.txt", filename)
+	its.Equal("this is only a test", string(data))
+}
+
+func TestReadMultipartFileExactSize(t *testing.T) {
+	its := assert.New(t)
+
+	req := newMultipartFileRequest(its, "file", "test.txt", "12345")
+	_, data, err := ReadMultipartFile(req, "file", 5)
+	its.Nil(err)
+	its.Equal("12345", string(data))
+}
+
+func TestReadMultipartFileTooLarge(t *testing.T) {
+	its := assert.New(t)
+
+	req := newMultipartFileRequest(its, "file", "test.txt", "this is only a test")
+	_, data, err := ReadMultipartFile(req, "file", 5)
+	its.True(ErrIsMultipartFileTooLarge(err))
+	its.Empty(data)
+}
+
+func TestReadMultipartFileMissing(t *testing.T) {
+	its := assert.New(t)
+
+	req := newMultipartFileRequest(its, "", "", "")
+	_, _, err := ReadMultipartFile(req, "file", 1024)
+	its.True(ErrIsParameterMissing(err))
+}
+
+func TestReadMultipartFileNotFile(t *testing.T) {
+	its := assert.New(t)
+
+	req := newMultipartFileRequest(its, "file", "test.txt", "this is only a test")
+	_, _, err := ReadMultipartFile(req, "note", 1024)
+	its.True(ErrIsMultipartFieldNotFile(err))
+}
+
+func TestReadMultipartFileNotMultipart(t *testing.T) {
+	its := assert.New(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewBufferString("{}"))
+	req.Header.Set(HeaderContentType, ContentTypeApplicationJSON)
+	_, _, err := ReadMultipartFile(req, "file", 1024)
+	its.NotNil(err)
+}
+
+func TestReadMultipartFileParsedForm(t *testing.T) {
+	its := assert.New(t)
+
+	req := newMultipartFileRequest(its, "file", "test.txt", "this is only a test")
+	its.Nil(req.ParseMultipartForm(DefaultPostedFilesMaxMemory))
+	defer func() { _ = req.MultipartForm.RemoveAll() }()
+
+	filename, data, err := ReadMultipartFile(req, "file", 1024)
+	its.Nil(err)
+	its.Equal("test.txt", filename)
+	its.Equal("this is only a test", string(data))
+
+	_, _, err = ReadMultipartFile(req, "file", 5)
+	its.True(ErrIsMultipartFileTooLarge(err))
+
+	_, _, err = ReadMultipartFile(req, "note", 1024)
+	its.True(ErrIsMultipartFieldNotFile(err))
+
+	_, _, err = ReadMultipartFile(req, "missing", 1024)
+	its.True(ErrIsParameterMissing(err))
+}
@@ -20,6 +20,8 @@ var (
 	_ ResponseWriter      = (*StatusResponseWriter)(nil)
 	_ http.ResponseWriter = (*StatusResponseWriter)(nil)
 	_ http.Flusher        = (*StatusResponseWriter)(nil)
+	_ http.Hijacker       = (*StatusResponseWriter)(nil)
+	_ http.Pusher         = (*StatusResponseWriter)(nil)
 	_ io.Closer           = (*StatusResponseWriter)(nil)
 )
 
@@ -47,6 +49,10 @@ type StatusResponseWriter struct {
 
 // Write writes the data to the response.
 func (rw *StatusResponseWriter) Write(b []byte) (int, error) {
+	// writing without a header implicitly writes a 200.
+	if rw.statusCode == 0 {
+		rw.statusCode = http.StatusOK
+	}
 	bytesWritten, err := rw.innerResponse.Write(b)
 	rw.contentLength += bytesWritten
 	return bytesWritten, err
@@ -67,8 +73,12 @@ func (rw *StatusResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 }
 
 // WriteHeader writes the status code (it is a somewhat poorly chosen method name from the standard library).
+//
+// Only the first status code is recorded, as later calls are ignored by the standard library.
 func (rw *StatusResponseWriter) WriteHeader(code int) {
-	rw.statusCode = code
+	if rw.statusCode == 0 {
+		rw.statusCode = code
+	}
 	rw.innerResponse.WriteHeader(code)
 }
 
@@ -84,8 +94,23 @@ func (rw *StatusResponseWriter) Flush() {
 	}
 }
 
+// Push calls push on the inner response writer if it is supported,
+// returning `http.ErrNotSupported` otherwise.
+func (rw *StatusResponseWriter) Push(target string, opts *http.PushOptions) error {
+	if typed, ok := rw.innerResponse.(http.Pusher); ok {
+		return typed.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
 // StatusCode returns the status code.
+//
+// It defaults to 200 if `WriteHeader` hasn't been called, as that's the status
+// the standard library writes for the response.
 func (rw *StatusResponseWriter) StatusCode() int {
+	if rw.statusCode == 0 {
+		return http.StatusOK
+	}
 	return rw.statusCode
 }
 
@@ -94,6 +119,11 @@ func (rw *StatusResponseWriter) ContentLength() int {
 	return rw.contentLength
 }
 
+// BytesWritten returns the number of body bytes written, and is the same as `ContentLength`.
+func (rw *StatusResponseWriter) BytesWritten() int {
+	return rw.contentLength
+}
+
 // Close calls close on the inner response if it supports it.
 func (rw *StatusResponseWriter) Close() error {
 	if typed, ok := rw.innerResponse.(io.Closer); ok {
@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/blend/go-sdk/assert"
@@ -68,3 +69,57 @@ func Test_StatusResponseWriter_self(t *testing.T) {
 	assert.Equal(http.StatusOK, rw.StatusCode())
 	assert.Equal("this is a test", output.String())
 }
+
+func Test_StatusResponseWriter_defaultStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	rw := NewStatusResponseWriter(httptest.NewRecorder())
+	assert.Equal(http.StatusOK, rw.StatusCode())
+	assert.Zero(rw.BytesWritten())
+
+	_, err := rw.Write([]byte("this is a test"))
+	assert.Nil(err)
+	rw.WriteHeader(http.StatusInternalServerError)
+	assert.Equal(http.StatusOK, rw.StatusCode())
+	assert.Equal(14, rw.BytesWritten())
+	assert.Equal(14, rw.ContentLength())
+}
+
+func Test_StatusResponseWriter_firstStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	rw := NewStatusResponseWriter(httptest.NewRecorder())
+	rw.WriteHeader(http.StatusNotFound)
+	rw.WriteHeader(http.StatusOK)
+	_, err := rw.Write([]byte("not found"))
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, rw.StatusCode())
+	assert.Equal(9, rw.BytesWritten())
+}
+
+type pushResponseWriter struct {
+	*httptest.ResponseRecorder
+	pushed []string
+}
+
+func (prw *pushResponseWriter) Push(target string, _ *http.PushOptions) error {
+	prw.pushed = append(prw.pushed, target)
+	return nil
+}
+
+func Test_StatusResponseWriter_forwarding(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := httptest.NewRecorder()
+	rw := NewStatusResponseWriter(recorder)
+	rw.Flush()
+	assert.True(recorder.Flushed)
+	assert.Equal(http.ErrNotSupported, rw.Push("/app.js", nil))
+	_, _, err := rw.Hijack()
+	assert.NotNil(err)
+
+	pusher := &pushResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+	rw = NewStatusResponseWriter(pusher)
+	assert.Nil(rw.Push("/app.js", nil))
+	assert.Equal([]string{"/app.js"}, pusher.pushed)
+}
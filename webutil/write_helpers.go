@@ -48,7 +48,9 @@ func WriteJSON(w http.ResponseWriter, statusCode int, response interface{}) erro
 	return nil
 }
 
-// WriteXML marshalls an object to json.
+// WriteXML marshalls an object to xml.
+//
+// It sets the content type header before writing the status code, as `WriteJSON` does.
 func WriteXML(w http.ResponseWriter, statusCode int, response interface{}) error {
 	w.Header().Set(HeaderContentType, ContentTypeXML)
 	w.WriteHeader(statusCode)
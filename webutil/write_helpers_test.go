@@ -97,6 +97,7 @@ func TestWriteXML(t *testing.T) {
 	res := NewMockResponse(buf)
 	assert.Nil(WriteXML(res, http.StatusOK, xmltest{Foo: "bar"}))
 	assert.Equal(http.StatusOK, res.StatusCode())
+	assert.Equal(ContentTypeXML, res.Header().Get(HeaderContentType))
 	assert.Equal("<xmltest><foo>bar</foo></xmltest>", buf.String())
 }
 